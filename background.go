@@ -0,0 +1,258 @@
+package vital
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// defaultPanicBackoff is the restart delay used when WithPanicRestart gets a non-positive one.
+const defaultPanicBackoff = time.Second
+
+// ErrBackgroundLoopNotStarted is returned when restarting a background loop that was never started.
+var ErrBackgroundLoopNotStarted = errors.New("background loop not started")
+
+// Restartable is a background component that can be restarted when its settings change,
+// for example after a configuration reload.
+type Restartable interface {
+	Restart(ctx context.Context) error
+}
+
+// RestartAll restarts each component in order and returns all restart errors joined.
+// A failing component does not prevent the remaining ones from being restarted.
+func RestartAll(ctx context.Context, components ...Restartable) error {
+	var errs error
+
+	for idx, component := range components {
+		err := component.Restart(ctx)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("restart component %d: %w", idx, err))
+		}
+	}
+
+	return errs
+}
+
+// Compile-time check that BackgroundLoop implements Restartable.
+var _ Restartable = (*BackgroundLoop)(nil)
+
+// BackgroundLoop runs a function in a goroutine until its context is canceled.
+// Restart stops the running goroutine and waits for it to exit before starting a new one,
+// so the old and new loop never run concurrently.
+type BackgroundLoop struct {
+	run            func(ctx context.Context)
+	logger         *slog.Logger
+	restartOnPanic bool
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	stopTimeout    time.Duration
+
+	mutex  sync.Mutex
+	parent context.Context //nolint:containedctx // Parent context for loops started by Restart.
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// BackgroundLoopOption is a functional option for configuring a BackgroundLoop.
+type BackgroundLoopOption func(*BackgroundLoop)
+
+// WithLoopLogger sets the structured logger used to report panics in the loop.
+// A nil logger is silently ignored; the default slog.Default() is kept.
+func WithLoopLogger(logger *slog.Logger) BackgroundLoopOption {
+	return func(l *BackgroundLoop) {
+		if logger == nil {
+			return
+		}
+
+		l.logger = logger
+	}
+}
+
+// WithPanicRestart restarts the loop after a panic instead of leaving it stopped.
+// The delay before each restart starts at initial and doubles after every consecutive
+// panic, up to maxBackoff. It resets to initial when a run lasted longer than the current
+// delay. An initial value less than or equal to zero uses one second, and a maxBackoff
+// below initial uses initial.
+func WithPanicRestart(initial, maxBackoff time.Duration) BackgroundLoopOption {
+	if initial <= 0 {
+		initial = defaultPanicBackoff
+	}
+
+	return func(l *BackgroundLoop) {
+		l.restartOnPanic = true
+		l.initialBackoff = initial
+		l.maxBackoff = max(maxBackoff, initial)
+	}
+}
+
+// WithLoopShutdownTimeout bounds how long Shutdown waits for the loop to exit, in addition
+// to the deadline of the context passed to Shutdown. A value less than or equal to zero
+// relies on the context alone, which is the default.
+func WithLoopShutdownTimeout(timeout time.Duration) BackgroundLoopOption {
+	return func(l *BackgroundLoop) {
+		l.stopTimeout = timeout
+	}
+}
+
+// NewBackgroundLoop creates a BackgroundLoop for run. The loop is not started.
+// run must return promptly once its context is canceled.
+// Panics in run are recovered and logged; by default the loop then stays stopped.
+func NewBackgroundLoop(run func(ctx context.Context), opts ...BackgroundLoopOption) *BackgroundLoop {
+	//nolint:exhaustruct // Lifecycle fields are set by Start
+	loop := &BackgroundLoop{
+		run:    run,
+		logger: slog.Default(),
+	}
+
+	for _, opt := range opts {
+		opt(loop)
+	}
+
+	return loop
+}
+
+// Start runs the loop in a new goroutine bound to ctx.
+// It is a no-op if the loop is still running.
+func (l *BackgroundLoop) Start(ctx context.Context) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	l.parent = ctx
+
+	if l.done != nil {
+		select {
+		case <-l.done:
+		default:
+			return
+		}
+	}
+
+	l.startLocked()
+}
+
+// Restart stops the running loop, waits for it to exit, and starts it again with the
+// context passed to Start. ctx bounds how long Restart waits for the old goroutine; if it
+// expires, the loop is left stopped and an error is returned.
+func (l *BackgroundLoop) Restart(ctx context.Context) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.parent == nil {
+		return ErrBackgroundLoopNotStarted
+	}
+
+	err := l.stopLocked(ctx)
+	if err != nil {
+		return err
+	}
+
+	l.startLocked()
+
+	return nil
+}
+
+// Shutdown stops the loop and waits for it to exit, for ctx to expire, or for the timeout
+// set with WithLoopShutdownTimeout to elapse.
+// It matches ShutdownFunc so it can be registered with WithShutdownFunc.
+func (l *BackgroundLoop) Shutdown(ctx context.Context) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	stopCtx, cancel := contextWithTimeoutIfNeeded(ctx, l.stopTimeout)
+	if cancel != nil {
+		defer cancel()
+	}
+
+	return l.stopLocked(stopCtx)
+}
+
+func (l *BackgroundLoop) startLocked() {
+	loopCtx, cancel := context.WithCancel(l.parent)
+	done := make(chan struct{})
+
+	l.cancel = cancel
+	l.done = done
+
+	go func() {
+		defer close(done)
+
+		l.runWithRecovery(loopCtx)
+	}()
+}
+
+func (l *BackgroundLoop) runWithRecovery(ctx context.Context) {
+	backoff := l.initialBackoff
+
+	for {
+		startedAt := time.Now()
+
+		if !l.runOnce(ctx) || !l.restartOnPanic {
+			return
+		}
+
+		// A run that outlasted the current delay is not part of a crash loop.
+		if time.Since(startedAt) > backoff {
+			backoff = l.initialBackoff
+		}
+
+		timer := time.NewTimer(backoff)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return
+		case <-timer.C:
+		}
+
+		backoff = min(backoff*2, l.maxBackoff)
+	}
+}
+
+// runOnce runs the loop function and reports whether it panicked.
+func (l *BackgroundLoop) runOnce(ctx context.Context) (panicked bool) { //nolint:nonamedreturns // Set by recover.
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			panicked = true
+
+			reportPanic(ctx, l.logger, componentBackgroundLoop, "background loop panicked", recovered)
+		}
+	}()
+
+	l.run(ctx)
+
+	return false
+}
+
+func (l *BackgroundLoop) stopLocked(ctx context.Context) error {
+	if l.done == nil {
+		return nil
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	l.cancel()
+
+	select {
+	case <-l.done:
+		l.cancel = nil
+		l.done = nil
+
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("wait for background loop: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,329 @@
+package vital_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/monkescience/testastic"
+	"github.com/monkescience/vital"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+func TestBackgroundLoop(t *testing.T) {
+	t.Parallel()
+	t.Run("restarts without overlapping runs", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a started loop that tracks concurrent runs
+		var (
+			running    atomic.Int32
+			overlapped atomic.Bool
+			starts     atomic.Int32
+		)
+
+		loop := vital.NewBackgroundLoop(func(ctx context.Context) {
+			if running.Add(1) > 1 {
+				overlapped.Store(true)
+			}
+
+			starts.Add(1)
+			<-ctx.Done()
+			time.Sleep(10 * time.Millisecond)
+			running.Add(-1)
+		})
+		loop.Start(t.Context())
+
+		// when: restarting the loop several times
+		for range 3 {
+			err := loop.Restart(t.Context())
+			testastic.NoError(t, err)
+		}
+
+		err := loop.Shutdown(t.Context())
+		testastic.NoError(t, err)
+
+		// then: every run should have started and none should overlap
+		testastic.Equal(t, int32(4), starts.Load())
+		testastic.False(t, overlapped.Load())
+		testastic.Equal(t, int32(0), running.Load())
+	})
+
+	t.Run("returns error when restarted before start", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a loop that was never started
+		loop := vital.NewBackgroundLoop(func(ctx context.Context) { <-ctx.Done() })
+
+		// when: restarting the loop
+		err := loop.Restart(t.Context())
+
+		// then: it should report that the loop was not started
+		testastic.ErrorIs(t, err, vital.ErrBackgroundLoopNotStarted)
+	})
+
+	t.Run("stops waiting when context expires", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a loop that ignores cancellation until released
+		release := make(chan struct{})
+		loop := vital.NewBackgroundLoop(func(_ context.Context) { <-release })
+		loop.Start(t.Context())
+
+		ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+		defer cancel()
+
+		// when: restarting the loop with a short deadline
+		err := loop.Restart(ctx)
+
+		// then: it should return the context error
+		testastic.ErrorIs(t, err, context.DeadlineExceeded)
+
+		close(release)
+
+		err = loop.Shutdown(t.Context())
+		testastic.NoError(t, err)
+	})
+
+	t.Run("bounds shutdown by the loop shutdown timeout", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a loop with a short shutdown timeout that ignores cancellation
+		release := make(chan struct{})
+		loop := vital.NewBackgroundLoop(
+			func(_ context.Context) { <-release },
+			vital.WithLoopShutdownTimeout(20*time.Millisecond),
+		)
+		loop.Start(t.Context())
+
+		// when: shutting down with a context without deadline
+		err := loop.Shutdown(t.Context())
+
+		// then: it should give up after the loop shutdown timeout
+		testastic.ErrorIs(t, err, context.DeadlineExceeded)
+
+		close(release)
+
+		err = loop.Shutdown(t.Context())
+		testastic.NoError(t, err)
+	})
+}
+
+func TestRestartAll(t *testing.T) {
+	t.Parallel()
+	t.Run("restarts every component and joins errors", func(t *testing.T) {
+		t.Parallel()
+
+		// given: one started and one unstarted loop
+		var starts atomic.Int32
+
+		started := vital.NewBackgroundLoop(func(ctx context.Context) {
+			starts.Add(1)
+			<-ctx.Done()
+		})
+		started.Start(t.Context())
+
+		unstarted := vital.NewBackgroundLoop(func(ctx context.Context) { <-ctx.Done() })
+
+		// when: restarting both
+		err := vital.RestartAll(t.Context(), unstarted, started)
+
+		// then: the started loop should restart and the error should be reported
+		testastic.ErrorIs(t, err, vital.ErrBackgroundLoopNotStarted)
+
+		shutdownErr := started.Shutdown(t.Context())
+		testastic.NoError(t, shutdownErr)
+		testastic.Equal(t, int32(2), starts.Load())
+	})
+}
+
+func TestBackgroundLoop_Panics(t *testing.T) {
+	t.Parallel()
+	t.Run("logs panic and stays stopped by default", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a loop that panics, with a captured logger
+		var buf bytes.Buffer
+
+		logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+		var runs atomic.Int32
+
+		loop := vital.NewBackgroundLoop(func(_ context.Context) {
+			runs.Add(1)
+			panic("poller exploded")
+		}, vital.WithLoopLogger(logger))
+
+		// when: starting the loop and waiting for it to exit
+		loop.Start(t.Context())
+
+		err := loop.Shutdown(t.Context())
+		testastic.NoError(t, err)
+
+		// then: the panic should be logged once and the loop not restarted
+		testastic.Equal(t, int32(1), runs.Load())
+
+		var logEntry map[string]any
+
+		err = json.Unmarshal(buf.Bytes(), &logEntry)
+		testastic.NoError(t, err)
+
+		testastic.DeepEqual[any](t, "background loop panicked", logEntry["msg"])
+		testastic.DeepEqual[any](t, "poller exploded", logEntry["panic"])
+		testastic.MapHasKey(t, logEntry, "stack")
+	})
+
+	t.Run("restarts after panic with backoff", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a loop that panics twice before running normally
+		var runs atomic.Int32
+
+		recovered := make(chan struct{})
+
+		loop := vital.NewBackgroundLoop(func(ctx context.Context) {
+			if runs.Add(1) <= 2 {
+				panic("transient failure")
+			}
+
+			close(recovered)
+			<-ctx.Done()
+		},
+			vital.WithLoopLogger(slog.New(slog.DiscardHandler)),
+			vital.WithPanicRestart(time.Millisecond, 5*time.Millisecond),
+		)
+
+		// when: starting the loop
+		loop.Start(t.Context())
+
+		// then: it should be restarted until it runs normally
+		select {
+		case <-recovered:
+		case <-time.After(time.Second):
+			t.Fatal("loop was not restarted after panic")
+		}
+
+		err := loop.Shutdown(t.Context())
+		testastic.NoError(t, err)
+		testastic.Equal(t, int32(3), runs.Load())
+	})
+
+	t.Run("clamps non-positive backoff", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a loop that always panics, with a zero restart backoff
+		var runs atomic.Int32
+
+		loop := vital.NewBackgroundLoop(func(_ context.Context) {
+			runs.Add(1)
+			panic("permanent failure")
+		},
+			vital.WithLoopLogger(slog.New(slog.DiscardHandler)),
+			vital.WithPanicRestart(0, 0),
+		)
+
+		// when: running the loop briefly
+		loop.Start(t.Context())
+		time.Sleep(50 * time.Millisecond)
+
+		err := loop.Shutdown(t.Context())
+
+		// then: it should wait the default backoff instead of spinning
+		testastic.NoError(t, err)
+		testastic.Equal(t, int32(1), runs.Load())
+	})
+
+	t.Run("resets backoff after a long run", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a loop that panics quickly until the backoff has grown, then panics
+		// after running longer than the backoff
+		var (
+			runs      atomic.Int32
+			panicked  atomic.Int64
+			restarted = make(chan time.Duration, 1)
+		)
+
+		loop := vital.NewBackgroundLoop(func(ctx context.Context) {
+			run := runs.Add(1)
+
+			switch {
+			case run <= 6:
+			case run == 7:
+				time.Sleep(100 * time.Millisecond)
+			default:
+				restarted <- time.Since(time.Unix(0, panicked.Load()))
+				<-ctx.Done()
+
+				return
+			}
+
+			panicked.Store(time.Now().UnixNano())
+			panic("failure")
+		},
+			vital.WithLoopLogger(slog.New(slog.DiscardHandler)),
+			vital.WithPanicRestart(time.Millisecond, time.Hour),
+		)
+
+		// when: running the loop until it recovers
+		loop.Start(t.Context())
+
+		var delay time.Duration
+
+		select {
+		case delay = <-restarted:
+		case <-time.After(5 * time.Second):
+			t.Fatal("loop was not restarted after panic")
+		}
+
+		err := loop.Shutdown(t.Context())
+		testastic.NoError(t, err)
+
+		// then: the restart after the long run should use the initial backoff, not 64ms
+		testastic.Less(t, delay, 50*time.Millisecond)
+	})
+}
+
+func TestBackgroundLoopPanicMetric(t *testing.T) {
+	// Not parallel: replaces the global meter provider.
+
+	// given: a recording global meter provider and a loop that panics once
+	provider := &recordingMeterProvider{}
+	otel.SetMeterProvider(provider)
+
+	t.Cleanup(func() { otel.SetMeterProvider(noop.NewMeterProvider()) })
+
+	recovered := make(chan struct{})
+
+	var runs atomic.Int32
+
+	loop := vital.NewBackgroundLoop(func(ctx context.Context) {
+		if runs.Add(1) == 1 {
+			panic("failure")
+		}
+
+		close(recovered)
+		<-ctx.Done()
+	},
+		vital.WithLoopLogger(slog.New(slog.DiscardHandler)),
+		vital.WithPanicRestart(time.Millisecond, time.Millisecond),
+	)
+
+	// when: the loop panics and is restarted
+	loop.Start(t.Context())
+	<-recovered
+
+	err := loop.Shutdown(t.Context())
+	testastic.NoError(t, err)
+
+	// then: the panic should be counted for the background loop component
+	panics := provider.recorded("vital.panics")
+	testastic.Len(t, panics, 1)
+	testastic.Equal(t, 1.0, panics[0].value)
+	testastic.Equal(t, "background_loop", panics[0].component)
+}
@@ -0,0 +1,172 @@
+package vital
+
+import (
+	"log/slog"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	versionPath = "/version"
+	develModule = "(devel)"
+)
+
+// Build metadata overrides, set at link time with
+// -ldflags "-X github.com/monkescience/vital.buildVersion=v1.2.3".
+// They take precedence over the values embedded by the Go toolchain.
+//
+//nolint:gochecknoglobals // Set by the linker
+var (
+	buildVersion  string
+	buildRevision string
+	buildTime     string
+)
+
+// processStartTime approximates the process start with package initialization.
+//
+//nolint:gochecknoglobals // Set once at startup for uptime reporting
+var processStartTime = time.Now()
+
+// BuildInfo describes the running binary, as embedded by the Go toolchain or set with
+// linker flags. Fields other than GoVersion are empty when the information is unavailable.
+type BuildInfo struct {
+	Version   string `json:"version,omitempty"`
+	Revision  string `json:"revision,omitempty"`
+	Time      string `json:"time,omitempty"`
+	Modified  bool   `json:"modified,omitempty"`
+	GoVersion string `json:"goVersion"`
+}
+
+//nolint:gochecknoglobals // Build information does not change while the process runs
+var readBuildInfoOnce = sync.OnceValue(func() BuildInfo {
+	info := BuildInfo{GoVersion: runtime.Version()}
+
+	buildInfo, ok := debug.ReadBuildInfo()
+	if ok {
+		applyDebugBuildInfo(&info, buildInfo)
+	}
+
+	applyBuildOverrides(&info)
+
+	return info
+})
+
+func applyDebugBuildInfo(info *BuildInfo, buildInfo *debug.BuildInfo) {
+	if buildInfo.Main.Version != develModule {
+		info.Version = buildInfo.Main.Version
+	}
+
+	for _, setting := range buildInfo.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			info.Revision = setting.Value
+		case "vcs.time":
+			info.Time = setting.Value
+		case "vcs.modified":
+			info.Modified = setting.Value == "true"
+		}
+	}
+}
+
+func applyBuildOverrides(info *BuildInfo) {
+	if buildVersion != "" {
+		info.Version = buildVersion
+	}
+
+	if buildRevision != "" {
+		info.Revision = buildRevision
+		info.Modified = false
+	}
+
+	if buildTime != "" {
+		info.Time = buildTime
+	}
+}
+
+// ReadBuildInfo returns the build information of the running binary.
+// The result is read once and cached.
+func ReadBuildInfo() BuildInfo {
+	return readBuildInfoOnce()
+}
+
+// LogValue implements slog.LogValuer, so the build info can be attached to every record
+// with logger.With(slog.Any("build", vital.ReadBuildInfo())).
+func (b BuildInfo) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, 4) //nolint:mnd // At most four fields are logged
+
+	if b.Version != "" {
+		attrs = append(attrs, slog.String("version", b.Version))
+	}
+
+	if b.Revision != "" {
+		attrs = append(attrs, slog.String("revision", b.Revision))
+	}
+
+	if b.Time != "" {
+		attrs = append(attrs, slog.String("time", b.Time))
+	}
+
+	attrs = append(attrs, slog.String("go_version", b.GoVersion))
+
+	return slog.GroupValue(attrs...)
+}
+
+// Attributes returns the build info as OpenTelemetry semantic convention attributes,
+// for use in a resource when configuring a tracer or meter provider.
+func (b BuildInfo) Attributes() []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.String("process.runtime.version", b.GoVersion),
+	}
+
+	if b.Version != "" {
+		attrs = append(attrs, attribute.String("service.version", b.Version))
+	}
+
+	if b.Revision != "" {
+		attrs = append(attrs, attribute.String("vcs.ref.head.revision", b.Revision))
+	}
+
+	return attrs
+}
+
+// VersionHandlerFunc returns an HTTP handler function that responds with ReadBuildInfo as JSON.
+func VersionHandlerFunc() http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		NoCache(writer)
+		respondJSON(req.Context(), writer, http.StatusOK, ReadBuildInfo())
+	}
+}
+
+// Uptime returns the time elapsed since the process started.
+func Uptime() time.Duration {
+	return time.Since(processStartTime)
+}
+
+// responseInfo selects the optional process metadata included in health responses.
+type responseInfo struct {
+	build  bool
+	uptime bool
+}
+
+func (i responseInfo) buildInfo() *BuildInfo {
+	if !i.build {
+		return nil
+	}
+
+	info := ReadBuildInfo()
+
+	return &info
+}
+
+func (i responseInfo) uptimeString() string {
+	if !i.uptime {
+		return ""
+	}
+
+	return Uptime().Truncate(time.Second).String()
+}
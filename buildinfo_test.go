@@ -0,0 +1,203 @@
+package vital_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/monkescience/testastic"
+	"github.com/monkescience/vital"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestReadBuildInfo(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reports the go version", func(t *testing.T) {
+		t.Parallel()
+
+		// when: reading the build info
+		info := vital.ReadBuildInfo()
+
+		// then: it should report the runtime go version
+		testastic.Equal(t, runtime.Version(), info.GoVersion)
+	})
+}
+
+func TestBuildInfoLogValue(t *testing.T) {
+	t.Parallel()
+
+	t.Run("logs build info as a group", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a logger writing JSON and a build info
+		var buf bytes.Buffer
+
+		logger := slog.New(slog.NewJSONHandler(&buf, nil))
+		info := vital.BuildInfo{Version: "v1.2.3", Revision: "abc123", GoVersion: "go1.26.0"}
+
+		// when: logging with the build info attached
+		logger.With(slog.Any("build", info)).Info("started")
+
+		// then: the fields should be nested under the build group
+		var entry map[string]any
+
+		err := json.Unmarshal(buf.Bytes(), &entry)
+		testastic.NoError(t, err)
+
+		build, ok := entry["build"].(map[string]any)
+		testastic.True(t, ok)
+		testastic.Equal(t, "v1.2.3", build["version"])
+		testastic.Equal(t, "abc123", build["revision"])
+		testastic.Equal(t, "go1.26.0", build["go_version"])
+		testastic.Nil(t, build["time"])
+	})
+}
+
+func TestBuildInfoAttributes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns semantic convention attributes", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a build info with version and revision
+		info := vital.BuildInfo{Version: "v1.2.3", Revision: "abc123", GoVersion: "go1.26.0"}
+
+		// when: converting it to attributes
+		attrs := attribute.NewSet(info.Attributes()...)
+
+		// then: the version, revision, and runtime should be present
+		version, _ := attrs.Value("service.version")
+		revision, _ := attrs.Value("vcs.ref.head.revision")
+		runtimeVersion, _ := attrs.Value("process.runtime.version")
+
+		testastic.Equal(t, "v1.2.3", version.AsString())
+		testastic.Equal(t, "abc123", revision.AsString())
+		testastic.Equal(t, "go1.26.0", runtimeVersion.AsString())
+	})
+
+	t.Run("omits empty fields", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a build info without version control information
+		info := vital.BuildInfo{GoVersion: "go1.26.0"}
+
+		// when: converting it to attributes
+		attrs := info.Attributes()
+
+		// then: only the runtime version should be present
+		testastic.Len(t, attrs, 1)
+	})
+}
+
+func TestUptime(t *testing.T) {
+	t.Parallel()
+
+	t.Run("increases over time", func(t *testing.T) {
+		t.Parallel()
+
+		// given: an initial uptime
+		first := vital.Uptime()
+
+		// when: time passes
+		time.Sleep(time.Millisecond)
+
+		// then: the uptime should increase
+		testastic.Greater(t, vital.Uptime(), first)
+	})
+}
+
+func TestHealthHandlerBuildInfo(t *testing.T) {
+	t.Parallel()
+
+	t.Run("omitted by default", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a health handler without build info options
+		handler := vital.NewHealthHandler()
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/livez", nil)
+
+		// when: calling the liveness endpoint
+		handler.ServeHTTP(recorder, req)
+
+		// then: the response should not include build info or uptime
+		var response vital.LiveResponse
+
+		err := json.NewDecoder(recorder.Body).Decode(&response)
+		testastic.NoError(t, err)
+		testastic.Nil(t, response.Build)
+		testastic.Equal(t, "", response.Uptime)
+	})
+
+	for _, path := range []string{"/livez", "/startupz", "/readyz"} {
+		t.Run("included in "+path, func(t *testing.T) {
+			t.Parallel()
+
+			// given: a health handler with build info and uptime
+			handler := vital.NewHealthHandler(vital.WithBuildInfo(), vital.WithUptime())
+			recorder := httptest.NewRecorder()
+			req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, path, nil)
+
+			// when: calling the endpoint
+			handler.ServeHTTP(recorder, req)
+
+			// then: the response should include build info and uptime
+			testastic.Equal(t, http.StatusOK, recorder.Code)
+
+			var response vital.ReadyResponse
+
+			err := json.NewDecoder(recorder.Body).Decode(&response)
+			testastic.NoError(t, err)
+			testastic.NotNil(t, response.Build)
+			testastic.Equal(t, runtime.Version(), response.Build.GoVersion)
+			testastic.NotEqual(t, "", response.Uptime)
+		})
+	}
+}
+
+func TestVersionEndpoint(t *testing.T) {
+	t.Parallel()
+
+	t.Run("served with build info", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a health handler with build info
+		handler := vital.NewHealthHandler(vital.WithBuildInfo())
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/version", nil)
+
+		// when: calling the version endpoint
+		handler.ServeHTTP(recorder, req)
+
+		// then: it should respond with the build info
+		testastic.Equal(t, http.StatusOK, recorder.Code)
+
+		var info vital.BuildInfo
+
+		err := json.NewDecoder(recorder.Body).Decode(&info)
+		testastic.NoError(t, err)
+		testastic.Equal(t, vital.ReadBuildInfo(), info)
+	})
+
+	t.Run("not served without build info", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a health handler without build info
+		handler := vital.NewHealthHandler()
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/version", nil)
+
+		// when: calling the version endpoint
+		handler.ServeHTTP(recorder, req)
+
+		// then: it should not be found
+		testastic.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+}
@@ -0,0 +1,65 @@
+package vital
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CacheDirective is a single Cache-Control response directive.
+type CacheDirective string
+
+// Cache-Control directives without arguments.
+const (
+	CachePublic         CacheDirective = "public"
+	CachePrivate        CacheDirective = "private"
+	CacheNoCache        CacheDirective = "no-cache"
+	CacheNoStore        CacheDirective = "no-store"
+	CacheMustRevalidate CacheDirective = "must-revalidate"
+	CacheImmutable      CacheDirective = "immutable"
+)
+
+// MaxAge returns a max-age directive. Durations are truncated to whole seconds.
+func MaxAge(d time.Duration) CacheDirective {
+	return secondsDirective("max-age", d)
+}
+
+// SharedMaxAge returns an s-maxage directive for shared caches such as CDNs.
+func SharedMaxAge(d time.Duration) CacheDirective {
+	return secondsDirective("s-maxage", d)
+}
+
+// StaleWhileRevalidate returns a stale-while-revalidate directive.
+func StaleWhileRevalidate(d time.Duration) CacheDirective {
+	return secondsDirective("stale-while-revalidate", d)
+}
+
+// StaleIfError returns a stale-if-error directive.
+func StaleIfError(d time.Duration) CacheDirective {
+	return secondsDirective("stale-if-error", d)
+}
+
+// SetCacheControl sets the Cache-Control header from directives, replacing any existing value.
+func SetCacheControl(writer http.ResponseWriter, directives ...CacheDirective) {
+	values := make([]string, 0, len(directives))
+	for _, directive := range directives {
+		values = append(values, string(directive))
+	}
+
+	writer.Header().Set("Cache-Control", strings.Join(values, ", "))
+}
+
+// NoCache sets headers that prevent clients and proxies from caching the response,
+// including the legacy Pragma and Expires headers. Health endpoints use it for every response.
+func NoCache(writer http.ResponseWriter) {
+	SetCacheControl(writer, CacheNoStore, CacheNoCache)
+	writer.Header().Set("Pragma", "no-cache")
+	writer.Header().Set("Expires", "Thu, 01 Jan 1970 00:00:00 GMT")
+}
+
+func secondsDirective(name string, d time.Duration) CacheDirective {
+	seconds := max(int64(d/time.Second), 0)
+
+	return CacheDirective(name + "=" + strconv.FormatInt(seconds, 10))
+}
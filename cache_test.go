@@ -0,0 +1,65 @@
+package vital_test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/monkescience/testastic"
+	"github.com/monkescience/vital"
+)
+
+func TestSetCacheControl(t *testing.T) {
+	t.Parallel()
+	t.Run("joins directives in order", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a response recorder
+		responseRecorder := httptest.NewRecorder()
+
+		// when: setting cache directives
+		vital.SetCacheControl(responseRecorder,
+			vital.CachePublic,
+			vital.MaxAge(time.Minute),
+			vital.SharedMaxAge(5*time.Minute),
+			vital.StaleWhileRevalidate(30*time.Second),
+		)
+
+		// then: the header should contain all directives in seconds
+		testastic.Equal(t,
+			"public, max-age=60, s-maxage=300, stale-while-revalidate=30",
+			responseRecorder.Header().Get("Cache-Control"),
+		)
+	})
+
+	t.Run("allows zero max-age", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a response recorder
+		responseRecorder := httptest.NewRecorder()
+
+		// when: setting a zero max-age with revalidation
+		vital.SetCacheControl(responseRecorder, vital.CachePrivate, vital.MaxAge(0), vital.CacheMustRevalidate)
+
+		// then: max-age=0 should be kept
+		testastic.Equal(t, "private, max-age=0, must-revalidate", responseRecorder.Header().Get("Cache-Control"))
+	})
+}
+
+func TestNoCache(t *testing.T) {
+	t.Parallel()
+	t.Run("sets no-cache headers", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a response recorder
+		responseRecorder := httptest.NewRecorder()
+
+		// when: disabling caching
+		vital.NoCache(responseRecorder)
+
+		// then: all no-cache headers should be set
+		testastic.Equal(t, "no-store, no-cache", responseRecorder.Header().Get("Cache-Control"))
+		testastic.Equal(t, "no-cache", responseRecorder.Header().Get("Pragma"))
+		testastic.Equal(t, "Thu, 01 Jan 1970 00:00:00 GMT", responseRecorder.Header().Get("Expires"))
+	})
+}
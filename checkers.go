@@ -0,0 +1,144 @@
+package vital
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"runtime"
+)
+
+// Pinger is implemented by dependencies that can be checked with a ping,
+// such as *sql.DB.
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+type funcChecker struct {
+	name  string
+	check func(ctx context.Context) (Status, string)
+}
+
+func (c *funcChecker) Name() string {
+	return c.name
+}
+
+func (c *funcChecker) Check(ctx context.Context) (Status, string) {
+	return c.check(ctx)
+}
+
+// NewChecker creates a Checker from a name and a check function.
+func NewChecker(name string, check func(ctx context.Context) (Status, string)) Checker {
+	return &funcChecker{name: name, check: check}
+}
+
+// NewPingChecker creates a Checker that pings pinger, for example a *sql.DB.
+func NewPingChecker(name string, pinger Pinger) Checker {
+	return NewChecker(name, func(ctx context.Context) (Status, string) {
+		err := pinger.PingContext(ctx)
+		if err != nil {
+			return StatusError, err.Error()
+		}
+
+		return StatusOK, "connected"
+	})
+}
+
+// NewTCPChecker creates a Checker that succeeds when a TCP connection to address can be opened.
+func NewTCPChecker(name, address string) Checker {
+	return NewChecker(name, func(ctx context.Context) (Status, string) {
+		//nolint:exhaustruct // Zero-value dialer defaults are intended
+		dialer := net.Dialer{}
+
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err != nil {
+			return StatusError, err.Error()
+		}
+
+		_ = conn.Close()
+
+		return StatusOK, "reachable"
+	})
+}
+
+// NewHTTPChecker creates a Checker that sends a GET request to url and succeeds when the
+// response has expectedStatus. Redirects are not followed, so a redirect is checked against
+// expectedStatus instead of hiding the status of the endpoint itself.
+func NewHTTPChecker(name, url string, expectedStatus int) Checker {
+	//nolint:exhaustruct // Default transport and no client timeout; the check context bounds requests
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	return NewChecker(name, func(ctx context.Context) (Status, string) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return StatusError, err.Error()
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return StatusError, err.Error()
+		}
+
+		_ = resp.Body.Close()
+
+		if resp.StatusCode != expectedStatus {
+			return StatusError, fmt.Sprintf("unexpected status %d (want %d)", resp.StatusCode, expectedStatus)
+		}
+
+		return StatusOK, fmt.Sprintf("status %d", resp.StatusCode)
+	})
+}
+
+// NewDiskWritableChecker creates a Checker that succeeds when a file can be created in dir.
+func NewDiskWritableChecker(name, dir string) Checker {
+	return NewChecker(name, func(_ context.Context) (Status, string) {
+		file, err := os.CreateTemp(dir, ".vital-health-*")
+		if err != nil {
+			return StatusError, err.Error()
+		}
+
+		_, writeErr := file.WriteString("ok")
+		closeErr := file.Close()
+		removeErr := os.Remove(file.Name())
+
+		err = joinErrors(writeErr, closeErr, removeErr)
+		if err != nil {
+			return StatusError, err.Error()
+		}
+
+		return StatusOK, "writable"
+	})
+}
+
+// NewGoroutineChecker creates a Checker that fails when more than maxGoroutines are running.
+func NewGoroutineChecker(name string, maxGoroutines int) Checker {
+	return NewChecker(name, func(_ context.Context) (Status, string) {
+		count := runtime.NumGoroutine()
+		if count > maxGoroutines {
+			return StatusError, fmt.Sprintf("%d goroutines exceed limit of %d", count, maxGoroutines)
+		}
+
+		return StatusOK, fmt.Sprintf("%d goroutines", count)
+	})
+}
+
+// NewMemoryChecker creates a Checker that fails when the allocated heap exceeds maxHeapBytes.
+// Reading memory statistics briefly stops the world, so prefer it with a ReadinessPoller.
+func NewMemoryChecker(name string, maxHeapBytes uint64) Checker {
+	return NewChecker(name, func(_ context.Context) (Status, string) {
+		var stats runtime.MemStats
+
+		runtime.ReadMemStats(&stats)
+
+		if stats.HeapAlloc > maxHeapBytes {
+			return StatusError, fmt.Sprintf("heap %d bytes exceeds limit of %d", stats.HeapAlloc, maxHeapBytes)
+		}
+
+		return StatusOK, fmt.Sprintf("heap %d bytes", stats.HeapAlloc)
+	})
+}
@@ -0,0 +1,245 @@
+package vital_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/monkescience/testastic"
+	"github.com/monkescience/vital"
+)
+
+type fakePinger struct {
+	err error
+}
+
+func (f *fakePinger) PingContext(_ context.Context) error {
+	return f.err
+}
+
+func TestNewChecker(t *testing.T) {
+	t.Parallel()
+	t.Run("uses name and check function", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a checker built from a function
+		checker := vital.NewChecker("queue", func(_ context.Context) (vital.Status, string) {
+			return vital.StatusOK, "empty"
+		})
+
+		// when: running the check
+		status, msg := checker.Check(t.Context())
+
+		// then: it should report the function's result
+		testastic.Equal(t, "queue", checker.Name())
+		testastic.Equal(t, vital.StatusOK, status)
+		testastic.Equal(t, "empty", msg)
+	})
+}
+
+func TestNewPingChecker(t *testing.T) {
+	t.Parallel()
+	t.Run("succeeds when ping succeeds", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a ping checker for a healthy dependency
+		checker := vital.NewPingChecker("database", &fakePinger{})
+
+		// when: running the check
+		status, msg := checker.Check(t.Context())
+
+		// then: it should report connected
+		testastic.Equal(t, vital.StatusOK, status)
+		testastic.Equal(t, "connected", msg)
+	})
+
+	t.Run("fails when ping fails", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a ping checker for a failing dependency
+		checker := vital.NewPingChecker("database", &fakePinger{err: errors.New("connection refused")})
+
+		// when: running the check
+		status, msg := checker.Check(t.Context())
+
+		// then: it should report the ping error
+		testastic.Equal(t, vital.StatusError, status)
+		testastic.Equal(t, "connection refused", msg)
+	})
+}
+
+func TestNewTCPChecker(t *testing.T) {
+	t.Parallel()
+	t.Run("succeeds when address accepts connections", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a listening TCP socket
+		listener, err := (&net.ListenConfig{}).Listen(t.Context(), "tcp", "127.0.0.1:0")
+		testastic.NoError(t, err)
+
+		defer func() { _ = listener.Close() }()
+
+		checker := vital.NewTCPChecker("upstream", listener.Addr().String())
+
+		// when: running the check
+		status, _ := checker.Check(t.Context())
+
+		// then: it should succeed
+		testastic.Equal(t, vital.StatusOK, status)
+	})
+
+	t.Run("fails when address refuses connections", func(t *testing.T) {
+		t.Parallel()
+
+		// given: an address nobody listens on
+		listener, err := (&net.ListenConfig{}).Listen(t.Context(), "tcp", "127.0.0.1:0")
+		testastic.NoError(t, err)
+
+		address := listener.Addr().String()
+		_ = listener.Close()
+
+		checker := vital.NewTCPChecker("upstream", address)
+
+		// when: running the check
+		status, _ := checker.Check(t.Context())
+
+		// then: it should fail
+		testastic.Equal(t, vital.StatusError, status)
+	})
+}
+
+func TestNewHTTPChecker(t *testing.T) {
+	t.Parallel()
+	t.Run("succeeds on expected status", func(t *testing.T) {
+		t.Parallel()
+
+		// given: an upstream returning 204
+		upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer upstream.Close()
+
+		checker := vital.NewHTTPChecker("upstream", upstream.URL, http.StatusNoContent)
+
+		// when: running the check
+		status, _ := checker.Check(t.Context())
+
+		// then: it should succeed
+		testastic.Equal(t, vital.StatusOK, status)
+	})
+
+	t.Run("fails on unexpected status", func(t *testing.T) {
+		t.Parallel()
+
+		// given: an upstream returning 500
+		upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer upstream.Close()
+
+		checker := vital.NewHTTPChecker("upstream", upstream.URL, http.StatusOK)
+
+		// when: running the check
+		status, msg := checker.Check(t.Context())
+
+		// then: it should report the unexpected status
+		testastic.Equal(t, vital.StatusError, status)
+		testastic.Equal(t, "unexpected status 500 (want 200)", msg)
+	})
+
+	t.Run("does not follow redirects", func(t *testing.T) {
+		t.Parallel()
+
+		// given: an upstream whose health path redirects to a healthy page
+		mux := http.NewServeMux()
+		mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "/ok", http.StatusFound)
+		})
+		mux.HandleFunc("/ok", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		upstream := httptest.NewServer(mux)
+		defer upstream.Close()
+
+		expectingOK := vital.NewHTTPChecker("upstream", upstream.URL+"/health", http.StatusOK)
+		expectingRedirect := vital.NewHTTPChecker("upstream", upstream.URL+"/health", http.StatusFound)
+
+		// when: running the checks
+		okStatus, okMsg := expectingOK.Check(t.Context())
+		redirectStatus, _ := expectingRedirect.Check(t.Context())
+
+		// then: the redirect itself should be checked
+		testastic.Equal(t, vital.StatusError, okStatus)
+		testastic.Equal(t, "unexpected status 302 (want 200)", okMsg)
+		testastic.Equal(t, vital.StatusOK, redirectStatus)
+	})
+}
+
+func TestNewDiskWritableChecker(t *testing.T) {
+	t.Parallel()
+	t.Run("succeeds for writable directory", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a writable directory
+		checker := vital.NewDiskWritableChecker("disk", t.TempDir())
+
+		// when: running the check
+		status, _ := checker.Check(t.Context())
+
+		// then: it should succeed
+		testastic.Equal(t, vital.StatusOK, status)
+	})
+
+	t.Run("fails for missing directory", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a directory that does not exist
+		checker := vital.NewDiskWritableChecker("disk", filepath.Join(t.TempDir(), "missing"))
+
+		// when: running the check
+		status, _ := checker.Check(t.Context())
+
+		// then: it should fail
+		testastic.Equal(t, vital.StatusError, status)
+	})
+}
+
+func TestRuntimeCheckers(t *testing.T) {
+	t.Parallel()
+	t.Run("goroutine checker enforces limit", func(t *testing.T) {
+		t.Parallel()
+
+		// given: goroutine checkers with a generous and an impossible limit
+		generous := vital.NewGoroutineChecker("goroutines", 1_000_000)
+		impossible := vital.NewGoroutineChecker("goroutines", 0)
+
+		// when: running the checks
+		okStatus, _ := generous.Check(t.Context())
+		errStatus, _ := impossible.Check(t.Context())
+
+		// then: only the impossible limit should fail
+		testastic.Equal(t, vital.StatusOK, okStatus)
+		testastic.Equal(t, vital.StatusError, errStatus)
+	})
+
+	t.Run("memory checker enforces limit", func(t *testing.T) {
+		t.Parallel()
+
+		// given: memory checkers with a generous and an impossible limit
+		generous := vital.NewMemoryChecker("memory", 1<<40)
+		impossible := vital.NewMemoryChecker("memory", 0)
+
+		// when: running the checks
+		okStatus, _ := generous.Check(t.Context())
+		errStatus, _ := impossible.Check(t.Context())
+
+		// then: only the impossible limit should fail
+		testastic.Equal(t, vital.StatusOK, okStatus)
+		testastic.Equal(t, vital.StatusError, errStatus)
+	})
+}
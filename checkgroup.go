@@ -0,0 +1,110 @@
+package vital
+
+import (
+	"context"
+	"fmt"
+)
+
+// GroupPolicy sets how many members of a CheckerGroup must pass for the group to pass.
+type GroupPolicy struct {
+	required int
+	all      bool
+}
+
+// RequireAll passes a group only when every member passes.
+func RequireAll() GroupPolicy {
+	return GroupPolicy{all: true}
+}
+
+// RequireAny passes a group when at least one member passes.
+func RequireAny() GroupPolicy {
+	return RequireAtLeast(1)
+}
+
+// RequireAtLeast passes a group when at least n members pass. An n greater than the number
+// of members requires all of them.
+func RequireAtLeast(n int) GroupPolicy {
+	return GroupPolicy{required: n}
+}
+
+func (p GroupPolicy) requiredOf(total int) int {
+	if p.all {
+		return total
+	}
+
+	return min(p.required, total)
+}
+
+// CheckerGroup is a Checker that runs its members concurrently and aggregates their results
+// with a GroupPolicy, for dependencies such as replicated storage where not every member has
+// to be healthy. A group fails when too few members pass and is degraded when it passes with
+// failing members. Members count as passing when they are OK or degraded, or are
+// informational. The member results are included in the group's CheckResponse.
+type CheckerGroup struct {
+	name     string
+	policy   GroupPolicy
+	checkers []Checker
+}
+
+// NewCheckerGroup creates a CheckerGroup named name. Groups can be nested and configured
+// with ConfigureChecker like any other checker.
+func NewCheckerGroup(name string, policy GroupPolicy, checkers ...Checker) *CheckerGroup {
+	return &CheckerGroup{name: name, policy: policy, checkers: checkers}
+}
+
+// Name returns the group name.
+func (g *CheckerGroup) Name() string {
+	return g.name
+}
+
+// Check runs the members and returns the aggregated status.
+func (g *CheckerGroup) Check(ctx context.Context) (Status, string) {
+	status, msg, _ := g.check(ctx)
+
+	return status, msg
+}
+
+func (g *CheckerGroup) check(ctx context.Context) (Status, string, []CheckResponse) {
+	checks := runAllChecks(ctx, g.checkers)
+
+	passed := 0
+
+	for idx, check := range checks {
+		if check.Status != StatusError || isInformational(g.checkers[idx]) {
+			passed++
+		}
+	}
+
+	msg := fmt.Sprintf("%d of %d passing", passed, len(checks))
+
+	switch {
+	case passed < g.policy.requiredOf(len(checks)):
+		return StatusError, msg, checks
+	case overallStatus(g.checkers, checks) != StatusOK:
+		return StatusDegraded, msg, checks
+	default:
+		return StatusOK, msg, checks
+	}
+}
+
+// checkWithMembers runs chk and returns the member results of checker groups.
+func checkWithMembers(ctx context.Context, chk Checker) (Status, string, []CheckResponse) {
+	switch checker := chk.(type) {
+	case *configuredChecker:
+		checkCtx, cancel := contextWithTimeoutIfNeeded(ctx, checker.timeout)
+		if cancel != nil {
+			defer cancel()
+		}
+
+		status, msg, checks := checkWithMembers(checkCtx, checker.Checker)
+		status, msg = statusWithContextErr(checkCtx, status, msg)
+
+		return status, msg, checks
+	case *CheckerGroup:
+		return checker.check(ctx)
+	default:
+		status, msg := chk.Check(ctx)
+
+		return status, msg, nil
+	}
+}
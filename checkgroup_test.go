@@ -0,0 +1,188 @@
+package vital_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/monkescience/testastic"
+	"github.com/monkescience/vital"
+)
+
+func TestCheckerGroup(t *testing.T) {
+	t.Parallel()
+
+	healthy := &mockChecker{name: "healthy", status: vital.StatusOK}
+	failing := &mockChecker{name: "failing", status: vital.StatusError, message: "connection refused"}
+
+	tests := []struct {
+		name     string
+		policy   vital.GroupPolicy
+		checkers []vital.Checker
+		want     vital.Status
+		wantMsg  string
+	}{
+		{
+			"all passing",
+			vital.RequireAll(),
+			[]vital.Checker{healthy, healthy},
+			vital.StatusOK,
+			"2 of 2 passing",
+		},
+		{
+			"all with failure",
+			vital.RequireAll(),
+			[]vital.Checker{healthy, failing},
+			vital.StatusError,
+			"1 of 2 passing",
+		},
+		{
+			"any with failure",
+			vital.RequireAny(),
+			[]vital.Checker{healthy, failing},
+			vital.StatusDegraded,
+			"1 of 2 passing",
+		},
+		{
+			"any all failing",
+			vital.RequireAny(),
+			[]vital.Checker{failing, failing},
+			vital.StatusError,
+			"0 of 2 passing",
+		},
+		{
+			"quorum met",
+			vital.RequireAtLeast(2),
+			[]vital.Checker{healthy, healthy, failing},
+			vital.StatusDegraded,
+			"2 of 3 passing",
+		},
+		{
+			"quorum missed",
+			vital.RequireAtLeast(2),
+			[]vital.Checker{healthy, failing, failing},
+			vital.StatusError,
+			"1 of 3 passing",
+		},
+		{
+			"quorum above members",
+			vital.RequireAtLeast(5),
+			[]vital.Checker{healthy, healthy},
+			vital.StatusOK,
+			"2 of 2 passing",
+		},
+		{
+			"informational failure passes",
+			vital.RequireAll(),
+			[]vital.Checker{healthy, vital.ConfigureChecker(failing, vital.WithInformational())},
+			vital.StatusDegraded,
+			"2 of 2 passing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given: a checker group with a policy
+			group := vital.NewCheckerGroup("storage", tt.policy, tt.checkers...)
+
+			// when: running the group check
+			status, msg := group.Check(t.Context())
+
+			// then: the members should be aggregated by the policy
+			testastic.Equal(t, "storage", group.Name())
+			testastic.Equal(t, tt.want, status)
+			testastic.Equal(t, tt.wantMsg, msg)
+		})
+	}
+}
+
+func TestReadyHandler_CheckerGroups(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reports member results", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a group with one failing replica that tolerates a single failure
+		group := vital.NewCheckerGroup("storage", vital.RequireAny(),
+			&mockChecker{name: "primary", status: vital.StatusError, message: "connection refused"},
+			&mockChecker{name: "replica", status: vital.StatusOK},
+		)
+
+		handlers := vital.NewHealthHandler(vital.WithCheckers(group))
+		responseRecorder := httptest.NewRecorder()
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/readyz", nil)
+
+		// when: calling the ready endpoint
+		handlers.ServeHTTP(responseRecorder, req)
+
+		// then: the service should stay ready and report the group members
+		testastic.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response vital.ReadyResponse
+
+		err := json.NewDecoder(responseRecorder.Body).Decode(&response)
+		testastic.NoError(t, err)
+
+		testastic.Equal(t, vital.StatusDegraded, response.Status)
+		testastic.Len(t, response.Checks, 1)
+		testastic.Equal(t, vital.StatusDegraded, response.Checks[0].Status)
+		testastic.Len(t, response.Checks[0].Checks, 2)
+		testastic.Equal(t, "primary", response.Checks[0].Checks[0].Name)
+		testastic.Equal(t, "connection refused", response.Checks[0].Checks[0].Message)
+		testastic.Equal(t, vital.StatusOK, response.Checks[0].Checks[1].Status)
+	})
+
+	t.Run("failing group fails readiness", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a group that requires every member to pass
+		group := vital.NewCheckerGroup("downstream", vital.RequireAll(),
+			&mockChecker{name: "payments", status: vital.StatusOK},
+			&mockChecker{name: "inventory", status: vital.StatusError},
+		)
+
+		handlers := vital.NewHealthHandler(vital.WithCheckers(group))
+		responseRecorder := httptest.NewRecorder()
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/readyz", nil)
+
+		// when: calling the ready endpoint
+		handlers.ServeHTTP(responseRecorder, req)
+
+		// then: readiness should fail
+		testastic.Equal(t, http.StatusServiceUnavailable, responseRecorder.Code)
+	})
+
+	t.Run("configured group honors timeout", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a group with a slow member and a short group timeout
+		group := vital.NewCheckerGroup("cache", vital.RequireAll(),
+			&mockChecker{name: "slow", status: vital.StatusOK, delay: time.Second},
+		)
+
+		handlers := vital.NewHealthHandler(
+			vital.WithCheckers(vital.ConfigureChecker(group, vital.WithCheckTimeout(20*time.Millisecond))),
+		)
+		responseRecorder := httptest.NewRecorder()
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/readyz", nil)
+
+		// when: calling the ready endpoint
+		handlers.ServeHTTP(responseRecorder, req)
+
+		// then: the member should fail within the group timeout
+		testastic.Equal(t, http.StatusServiceUnavailable, responseRecorder.Code)
+
+		var response vital.ReadyResponse
+
+		err := json.NewDecoder(responseRecorder.Body).Decode(&response)
+		testastic.NoError(t, err)
+
+		testastic.Len(t, response.Checks[0].Checks, 1)
+		testastic.Equal(t, vital.StatusError, response.Checks[0].Checks[0].Status)
+	})
+}
@@ -0,0 +1,45 @@
+package vital
+
+import "context"
+
+// ContextValue is a strongly typed context value backed by a ContextKey, so it can be logged
+// by a ContextHandler without stringly typed ctx.Value lookups at the call sites.
+//
+//	var TenantID = vital.NewContextValue[string]("tenant_id")
+//
+//	ctx = TenantID.Set(ctx, "acme")
+//	tenant, ok := TenantID.Get(ctx)
+//
+//	handler := vital.NewContextHandler(base, vital.WithDefaultRegistry())
+type ContextValue[T any] struct {
+	key ContextKey
+}
+
+// NewContextValue creates a ContextValue logged under name and registers its key in the
+// DefaultRegistry, so handlers created with WithDefaultRegistry log it. Handlers with their
+// own registry log it once the key is added with WithContextKeys.
+// Values with the same name share the same ContextKey.
+func NewContextValue[T any](name string) ContextValue[T] {
+	key := ContextKey{Name: name}
+	DefaultRegistry().Register(key)
+
+	return ContextValue[T]{key: key}
+}
+
+// Set returns a copy of ctx carrying value.
+func (v ContextValue[T]) Set(ctx context.Context, value T) context.Context {
+	return context.WithValue(ctx, v.key, value)
+}
+
+// Get returns the value stored in ctx and reports whether it was present with type T.
+func (v ContextValue[T]) Get(ctx context.Context) (T, bool) {
+	value, ok := ctx.Value(v.key).(T)
+
+	return value, ok
+}
+
+// Key returns the ContextKey under which the value is stored, for registration with
+// WithContextKeys or a Registry.
+func (v ContextValue[T]) Key() ContextKey {
+	return v.key
+}
@@ -0,0 +1,72 @@
+package vital_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/monkescience/testastic"
+	"github.com/monkescience/vital"
+)
+
+func TestContextValue(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sets and gets typed values", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a typed context value
+		attempt := vital.NewContextValue[int]("attempt")
+
+		// when: setting and reading the value
+		ctx := attempt.Set(context.Background(), 3)
+		value, ok := attempt.Get(ctx)
+		_, missing := attempt.Get(context.Background())
+
+		// then: the value should round-trip with its type
+		testastic.True(t, ok)
+		testastic.Equal(t, 3, value)
+		testastic.False(t, missing)
+	})
+
+	t.Run("is logged by the context handler", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a context handler with the value's key registered
+		var buf bytes.Buffer
+
+		tenantID := vital.NewContextValue[string]("tenant_id")
+		logger := slog.New(vital.NewContextHandler(
+			slog.NewJSONHandler(&buf, nil),
+			vital.WithContextKeys(tenantID.Key()),
+		))
+
+		// when: logging with the value in the context
+		logger.InfoContext(tenantID.Set(context.Background(), "acme"), "request")
+
+		// then: the value should be logged under its name
+		testastic.Contains(t, buf.String(), `"tenant_id":"acme"`)
+	})
+
+	t.Run("is logged by handlers using the default registry", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a context handler using the default registry and no explicit keys
+		var buf bytes.Buffer
+
+		region := vital.NewContextValue[string]("test_context_value_region")
+		t.Cleanup(func() { vital.DefaultRegistry().Deregister(region.Key()) })
+
+		logger := slog.New(vital.NewContextHandler(
+			slog.NewJSONHandler(&buf, nil),
+			vital.WithDefaultRegistry(),
+		))
+
+		// when: logging with the value in the context
+		logger.InfoContext(region.Set(context.Background(), "eu-west"), "request")
+
+		// then: the value should be logged under its name
+		testastic.Contains(t, buf.String(), `"test_context_value_region":"eu-west"`)
+	})
+}
@@ -0,0 +1,61 @@
+package vital
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// DeadlineHeader is the header that carries a request deadline to a downstream service.
+const DeadlineHeader = "X-Request-Deadline"
+
+// RemainingBudget returns the time left until the deadline of ctx.
+// It reports false when ctx has no deadline.
+func RemainingBudget(ctx context.Context) (time.Duration, bool) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0, false
+	}
+
+	return time.Until(deadline), true
+}
+
+// WithDeadlineBudget returns a copy of ctx whose deadline is reserve earlier than the deadline
+// of ctx, so a downstream call times out while the caller still has time to handle the
+// failure. If the reserve exceeds the remaining budget, the returned context is already
+// expired. Without a deadline on ctx, the returned context has no deadline either.
+func WithDeadlineBudget(ctx context.Context, reserve time.Duration) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithDeadline(ctx, deadline.Add(-reserve))
+}
+
+// SetDeadlineHeader sets the DeadlineHeader of req to the deadline of its context, formatted
+// as RFC 3339 in UTC. Without a deadline on the context, the header is left unchanged.
+func SetDeadlineHeader(req *http.Request) {
+	deadline, ok := req.Context().Deadline()
+	if !ok {
+		return
+	}
+
+	req.Header.Set(DeadlineHeader, deadline.UTC().Format(time.RFC3339Nano))
+}
+
+// DeadlineFromHeader returns the deadline sent in the DeadlineHeader of r.
+// It reports false when the header is missing or not a valid RFC 3339 time.
+func DeadlineFromHeader(r *http.Request) (time.Time, bool) {
+	value := r.Header.Get(DeadlineHeader)
+	if value == "" {
+		return time.Time{}, false
+	}
+
+	deadline, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return deadline, true
+}
@@ -0,0 +1,182 @@
+package vital_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/monkescience/testastic"
+	"github.com/monkescience/vital"
+)
+
+func TestRemainingBudget(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reports time until deadline", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a context with a one minute deadline
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		// when: reading the remaining budget
+		remaining, ok := vital.RemainingBudget(ctx)
+
+		// then: it should be close to one minute
+		testastic.True(t, ok)
+		testastic.Greater(t, remaining, 59*time.Second)
+		testastic.LessOrEqual(t, remaining, time.Minute)
+	})
+
+	t.Run("reports false without deadline", func(t *testing.T) {
+		t.Parallel()
+
+		// when: reading the remaining budget of a context without deadline
+		_, ok := vital.RemainingBudget(context.Background())
+
+		// then: it should report no budget
+		testastic.False(t, ok)
+	})
+}
+
+func TestWithDeadlineBudget(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reserves time before the parent deadline", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a context with a deadline
+		parent, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		parentDeadline, _ := parent.Deadline()
+
+		// when: reserving ten seconds of the budget
+		ctx, cancelBudget := vital.WithDeadlineBudget(parent, 10*time.Second)
+		defer cancelBudget()
+
+		// then: the deadline should be ten seconds earlier
+		deadline, ok := ctx.Deadline()
+		testastic.True(t, ok)
+		testastic.Equal(t, parentDeadline.Add(-10*time.Second), deadline)
+	})
+
+	t.Run("expires when the reserve exceeds the budget", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a context with a short deadline
+		parent, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		// when: reserving more than the remaining budget
+		ctx, cancelBudget := vital.WithDeadlineBudget(parent, time.Minute)
+		defer cancelBudget()
+
+		// then: the context should already be expired
+		testastic.True(t, errors.Is(ctx.Err(), context.DeadlineExceeded))
+	})
+
+	t.Run("keeps no deadline without parent deadline", func(t *testing.T) {
+		t.Parallel()
+
+		// when: reserving budget on a context without deadline
+		ctx, cancel := vital.WithDeadlineBudget(context.Background(), time.Second)
+		defer cancel()
+
+		// then: the context should have no deadline
+		_, ok := ctx.Deadline()
+		testastic.False(t, ok)
+	})
+}
+
+func TestSetDeadlineHeader(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sets the context deadline", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a request whose context has a deadline
+		deadline := time.Now().Add(time.Minute)
+
+		ctx, cancel := context.WithDeadline(context.Background(), deadline)
+		defer cancel()
+
+		req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/", nil)
+
+		// when: setting the deadline header
+		vital.SetDeadlineHeader(req)
+
+		// then: the header should carry the deadline
+		testastic.Equal(t, deadline.UTC().Format(time.RFC3339Nano), req.Header.Get(vital.DeadlineHeader))
+	})
+
+	t.Run("leaves the header unset without deadline", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a request without deadline
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/", nil)
+
+		// when: setting the deadline header
+		vital.SetDeadlineHeader(req)
+
+		// then: the header should be missing
+		testastic.Equal(t, "", req.Header.Get(vital.DeadlineHeader))
+	})
+}
+
+func TestDeadlineFromHeader(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reads the deadline set by SetDeadlineHeader", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a request carrying a deadline header
+		deadline := time.Now().Add(time.Minute)
+
+		ctx, cancel := context.WithDeadline(context.Background(), deadline)
+		defer cancel()
+
+		outbound := httptest.NewRequestWithContext(ctx, http.MethodGet, "/", nil)
+		vital.SetDeadlineHeader(outbound)
+
+		inbound := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/", nil)
+		inbound.Header = outbound.Header
+
+		// when: reading the deadline
+		got, ok := vital.DeadlineFromHeader(inbound)
+
+		// then: it should match the original deadline
+		testastic.True(t, ok)
+		testastic.True(t, got.Equal(deadline))
+	})
+
+	t.Run("reports false without header", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a request without deadline header
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/", nil)
+
+		// when: reading the deadline
+		_, ok := vital.DeadlineFromHeader(req)
+
+		// then: it should report no deadline
+		testastic.False(t, ok)
+	})
+
+	t.Run("reports false for an invalid header", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a request with a malformed deadline header
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/", nil)
+		req.Header.Set(vital.DeadlineHeader, "soon")
+
+		// when: reading the deadline
+		_, ok := vital.DeadlineFromHeader(req)
+
+		// then: it should report no deadline
+		testastic.False(t, ok)
+	})
+}
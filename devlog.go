@@ -0,0 +1,224 @@
+package vital
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	devTimeFormat  = "15:04:05.000"
+	devShortIDSize = 8
+
+	ansiReset  = "\033[0m"
+	ansiDim    = "\033[2m"
+	ansiRed    = "\033[31m"
+	ansiYellow = "\033[33m"
+	ansiBlue   = "\033[34m"
+	ansiCyan   = "\033[36m"
+)
+
+// Compile-time check that devHandler implements slog.Handler.
+var _ slog.Handler = (*devHandler)(nil)
+
+// devHandler writes human-friendly, single-line records for local development:
+//
+//	12:04:05.123 INF request handled status=200 trace_id=4bf92f35
+//
+// Levels are colored unless the NO_COLOR environment variable is set, and trace and span
+// IDs are shortened. ReplaceAttr is not supported.
+type devHandler struct {
+	writer   io.Writer
+	mutex    *sync.Mutex
+	level    slog.Leveler
+	source   bool
+	color    bool
+	prefix   string
+	preAttrs []byte
+}
+
+func newDevHandler(writer io.Writer, opts *slog.HandlerOptions) *devHandler {
+	_, noColor := os.LookupEnv("NO_COLOR")
+
+	//nolint:exhaustruct // Prefix and preformatted attributes are set by WithGroup and WithAttrs
+	handler := &devHandler{
+		writer: writer,
+		mutex:  &sync.Mutex{},
+		level:  slog.LevelInfo,
+		color:  !noColor,
+	}
+
+	if opts != nil {
+		if opts.Level != nil {
+			handler.level = opts.Level
+		}
+
+		handler.source = opts.AddSource
+	}
+
+	return handler
+}
+
+func (h *devHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *devHandler) Handle(_ context.Context, record slog.Record) error {
+	buf := make([]byte, 0, 256) //nolint:mnd // Typical line length
+
+	if !record.Time.IsZero() {
+		buf = h.appendColored(buf, ansiDim, record.Time.Format(devTimeFormat))
+		buf = append(buf, ' ')
+	}
+
+	buf = h.appendColored(buf, devLevelColor(record.Level), devLevelName(record.Level))
+	buf = append(buf, ' ')
+	buf = append(buf, record.Message...)
+
+	if h.source && record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		buf = append(buf, ' ')
+		buf = h.appendColored(buf, ansiDim, frame.File+":"+strconv.Itoa(frame.Line))
+	}
+
+	buf = append(buf, h.preAttrs...)
+
+	record.Attrs(func(attr slog.Attr) bool {
+		buf = h.appendAttr(buf, h.prefix, attr)
+
+		return true
+	})
+
+	buf = append(buf, '\n')
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	_, err := h.writer.Write(buf)
+	if err != nil {
+		return fmt.Errorf("write dev log record: %w", err)
+	}
+
+	return nil
+}
+
+func (h *devHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	derived := *h
+	derived.preAttrs = slices.Clone(h.preAttrs)
+
+	for _, attr := range attrs {
+		derived.preAttrs = h.appendAttr(derived.preAttrs, h.prefix, attr)
+	}
+
+	return &derived
+}
+
+func (h *devHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	derived := *h
+	derived.prefix = h.prefix + name + "."
+
+	return &derived
+}
+
+func (h *devHandler) appendAttr(buf []byte, prefix string, attr slog.Attr) []byte {
+	value := attr.Value.Resolve()
+
+	if attr.Equal(slog.Attr{}) {
+		return buf
+	}
+
+	if value.Kind() == slog.KindGroup {
+		groupPrefix := prefix
+		if attr.Key != "" {
+			groupPrefix += attr.Key + "."
+		}
+
+		for _, groupAttr := range value.Group() {
+			buf = h.appendAttr(buf, groupPrefix, groupAttr)
+		}
+
+		return buf
+	}
+
+	text := devValueString(value)
+	if attr.Key == "trace_id" || attr.Key == "span_id" {
+		text = text[:min(len(text), devShortIDSize)]
+	}
+
+	buf = append(buf, ' ')
+	buf = h.appendColored(buf, ansiCyan, prefix+attr.Key+"=")
+
+	return append(buf, text...)
+}
+
+func (h *devHandler) appendColored(buf []byte, color, text string) []byte {
+	if !h.color {
+		return append(buf, text...)
+	}
+
+	buf = append(buf, color...)
+	buf = append(buf, text...)
+
+	return append(buf, ansiReset...)
+}
+
+func devValueString(value slog.Value) string {
+	var text string
+
+	switch value.Kind() {
+	case slog.KindTime:
+		text = value.Time().Format(time.RFC3339Nano)
+	case slog.KindAny:
+		if err, ok := value.Any().(error); ok {
+			text = err.Error()
+		} else {
+			text = value.String()
+		}
+	default:
+		text = value.String()
+	}
+
+	if text == "" || strings.ContainsAny(text, " \t\n\"=") {
+		return strconv.Quote(text)
+	}
+
+	return text
+}
+
+func devLevelName(level slog.Level) string {
+	switch {
+	case level >= slog.LevelError:
+		return "ERR"
+	case level >= slog.LevelWarn:
+		return "WRN"
+	case level >= slog.LevelInfo:
+		return "INF"
+	default:
+		return "DBG"
+	}
+}
+
+func devLevelColor(level slog.Level) string {
+	switch {
+	case level >= slog.LevelError:
+		return ansiRed
+	case level >= slog.LevelWarn:
+		return ansiYellow
+	case level >= slog.LevelInfo:
+		return ansiBlue
+	default:
+		return ansiDim
+	}
+}
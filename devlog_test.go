@@ -0,0 +1,77 @@
+package vital_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/monkescience/testastic"
+	"github.com/monkescience/vital"
+)
+
+//nolint:paralleltest // Tests modify the process environment
+func TestDevLogFormat(t *testing.T) {
+	t.Run("writes human-friendly lines with short trace ids", func(t *testing.T) {
+		// given: a dev format handler without colors and a span context
+		t.Setenv("NO_COLOR", "1")
+
+		var buf bytes.Buffer
+
+		handler, err := vital.NewHandlerFromConfig(vital.LogConfig{
+			Level:  "debug",
+			Format: "dev",
+			Writer: &buf,
+		}, vital.WithBuiltinKeys())
+		testastic.NoError(t, err)
+
+		ctx, spanCtx := testSpanContext(t)
+		logger := slog.New(handler).With(slog.String("service", "api")).WithGroup("http")
+
+		// when: logging a record with attributes
+		logger.InfoContext(ctx, "request handled",
+			slog.Int("status", 200),
+			slog.String("path", "/users list"),
+			slog.Any("err", errors.New("boom")),
+		)
+
+		// then: the line should be readable and the trace id shortened
+		line := buf.String()
+		_, rest, found := strings.Cut(line, " ")
+		testastic.True(t, found)
+
+		testastic.Equal(t,
+			`INF request handled service=api http.status=200 http.path="/users list" http.err=boom `+
+				"http.trace_id="+spanCtx.TraceID().String()[:8]+
+				" http.span_id="+spanCtx.SpanID().String()[:8]+
+				" http.trace_flags=01\n",
+			rest,
+		)
+	})
+
+	t.Run("respects the configured level", func(t *testing.T) {
+		// given: a dev format handler at warn level
+		t.Setenv("NO_COLOR", "1")
+
+		var buf bytes.Buffer
+
+		handler, err := vital.NewHandlerFromConfig(vital.LogConfig{
+			Level:  "warn",
+			Format: "dev",
+			Writer: &buf,
+		})
+		testastic.NoError(t, err)
+
+		logger := slog.New(handler)
+
+		// when: logging at info and error level
+		logger.InfoContext(context.Background(), "hidden")
+		logger.ErrorContext(context.Background(), "shown")
+
+		// then: only the error should be written
+		testastic.NotContains(t, buf.String(), "hidden")
+		testastic.Contains(t, buf.String(), "ERR shown")
+	})
+}
@@ -0,0 +1,79 @@
+package vital
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const errorAttrKey = "error"
+
+// ErrAttr returns an "error" attribute group holding the error message, its type, and the
+// types of the errors it wraps, so errors are logged with the same fields everywhere.
+// A nil error returns an empty attribute, which handlers ignore.
+func ErrAttr(err error) slog.Attr {
+	if err == nil {
+		return slog.Attr{}
+	}
+
+	attrs := []slog.Attr{
+		slog.String("message", err.Error()),
+		slog.String("type", fmt.Sprintf("%T", err)),
+	}
+
+	if chain := errorChain(err); len(chain) > 0 {
+		attrs = append(attrs, slog.Any("chain", chain))
+	}
+
+	return slog.Attr{Key: errorAttrKey, Value: slog.GroupValue(attrs...)}
+}
+
+// LogError logs msg at error level with ErrAttr(err) and attrs, and records err on the active
+// span. A nil logger uses slog.Default().
+func LogError(ctx context.Context, logger *slog.Logger, msg string, err error, attrs ...slog.Attr) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	all := make([]slog.Attr, 0, len(attrs)+1)
+	all = append(all, attrs...)
+	all = append(all, ErrAttr(err))
+
+	logger.LogAttrs(ctx, slog.LevelError, msg, all...)
+
+	if err != nil {
+		trace.SpanFromContext(ctx).RecordError(err)
+	}
+}
+
+// errorChain returns the types of the errors wrapped by err, depth first.
+func errorChain(err error) []string {
+	var chain []string
+
+	var walk func(error)
+
+	walk = func(current error) {
+		var wrapped []error
+
+		//nolint:errorlint // Unwrapping one level at a time is intended
+		switch unwrapper := current.(type) {
+		case interface{ Unwrap() error }:
+			if next := unwrapper.Unwrap(); next != nil {
+				wrapped = []error{next}
+			}
+		case interface{ Unwrap() []error }:
+			wrapped = unwrapper.Unwrap()
+		}
+
+		for _, next := range wrapped {
+			chain = append(chain, fmt.Sprintf("%T", next))
+			walk(next)
+		}
+	}
+
+	walk(err)
+
+	return chain
+}
@@ -0,0 +1,126 @@
+package vital_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"testing"
+
+	"github.com/monkescience/testastic"
+	"github.com/monkescience/vital"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestErrAttr(t *testing.T) {
+	t.Parallel()
+
+	t.Run("includes message, type, and wrapped chain", func(t *testing.T) {
+		t.Parallel()
+
+		// given: an error wrapping a path error
+		pathErr := &fs.PathError{Op: "open", Path: "/etc/app.yaml", Err: fs.ErrNotExist}
+		err := fmt.Errorf("load config: %w", pathErr)
+
+		// when: building the error attribute
+		attr := vital.ErrAttr(err)
+
+		// then: it should describe the error and what it wraps
+		testastic.Equal(t, "error", attr.Key)
+
+		fields := map[string]slog.Value{}
+		for _, field := range attr.Value.Group() {
+			fields[field.Key] = field.Value
+		}
+
+		testastic.Equal(t, err.Error(), fields["message"].String())
+		testastic.Equal(t, "*fmt.wrapError", fields["type"].String())
+		testastic.DeepEqual[any](t, []string{"*fs.PathError", "*errors.errorString"}, fields["chain"].Any())
+	})
+
+	t.Run("flattens joined errors", func(t *testing.T) {
+		t.Parallel()
+
+		// given: two joined errors
+		err := errors.Join(errors.New("first"), fmt.Errorf("second: %w", fs.ErrClosed))
+
+		// when: building the error attribute
+		attr := vital.ErrAttr(err)
+
+		// then: the chain should include every wrapped error depth first
+		var chain any
+
+		for _, field := range attr.Value.Group() {
+			if field.Key == "chain" {
+				chain = field.Value.Any()
+			}
+		}
+
+		testastic.DeepEqual[any](t, []string{"*errors.errorString", "*fmt.wrapError", "*errors.errorString"}, chain)
+	})
+
+	t.Run("returns empty attribute for nil error", func(t *testing.T) {
+		t.Parallel()
+
+		// when: building the attribute for a nil error
+		attr := vital.ErrAttr(nil)
+
+		// then: it should be empty
+		testastic.True(t, attr.Equal(slog.Attr{}))
+	})
+}
+
+func TestLogError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("logs error group and records it on the span", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a logger and a recording span
+		var buf bytes.Buffer
+
+		logger := slog.New(slog.NewJSONHandler(&buf, nil))
+		ctx, _, span := testRecordingSpan(t)
+
+		// when: logging an error
+		vital.LogError(ctx, logger, "sync failed", errors.New("upstream unavailable"), slog.String("job", "sync"))
+
+		// then: the record should carry the error group and the span an exception event
+		var entry map[string]any
+
+		err := json.Unmarshal(buf.Bytes(), &entry)
+		testastic.NoError(t, err)
+		testastic.Equal(t, "ERROR", entry["level"])
+		testastic.Equal(t, "sync", entry["job"])
+
+		errGroup, ok := entry["error"].(map[string]any)
+		testastic.True(t, ok)
+		testastic.Equal(t, "upstream unavailable", errGroup["message"])
+
+		events := span.recordedEvents()
+		testastic.Len(t, events, 1)
+		testastic.Equal(t, "exception", events[0].name)
+		testastic.DeepEqual(t, []attribute.KeyValue{
+			attribute.String("exception.message", "upstream unavailable"),
+		}, events[0].attrs)
+	})
+	t.Run("does not modify the caller's attrs", func(t *testing.T) {
+		t.Parallel()
+
+		// given: an attrs slice with spare capacity
+		logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+		attrs := make([]slog.Attr, 1, 2)
+		attrs[0] = slog.String("job", "sync")
+		spare := attrs[:2]
+		spare[1] = slog.String("marker", "untouched")
+
+		// when: logging an error with those attrs
+		vital.LogError(t.Context(), logger, "sync failed", errors.New("upstream unavailable"), attrs...)
+
+		// then: the spare capacity should be left alone
+		testastic.Equal(t, "marker", spare[1].Key)
+	})
+}
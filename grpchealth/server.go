@@ -0,0 +1,301 @@
+// Package grpchealth exposes vital health checkers through the standard grpc.health.v1
+// Health service, so HTTP and gRPC probes share one source of truth. It is a separate
+// module so that the vital module does not depend on gRPC.
+package grpchealth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/monkescience/vital"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultTimeout       = 2 * time.Second
+	defaultWatchInterval = 5 * time.Second
+)
+
+// ErrDuplicateChecker is returned by NewServer when two checkers share a name.
+var ErrDuplicateChecker = errors.New("duplicate checker name")
+
+// Compile-time check that Server implements the Health service.
+var _ healthpb.HealthServer = (*Server)(nil)
+
+// Server implements the grpc.health.v1 Health service on top of vital checkers.
+//
+// The empty service name reports overall readiness: NOT_SERVING when the ready function
+// returns false or a critical checker fails, and SERVING otherwise, including when the
+// service is degraded. Every checker is also exposed as a service named after the checker;
+// checking it runs only that checker.
+type Server struct {
+	healthpb.UnimplementedHealthServer
+
+	checkers       []vital.Checker
+	checkersByName map[string]vital.Checker
+	poller         *vital.ReadinessPoller
+	readyFunc      func() bool
+	timeout        time.Duration
+	watchInterval  time.Duration
+
+	// Watch streams share one evaluation per watch interval.
+	watchMutex sync.Mutex
+	watched    map[string]healthpb.HealthCheckResponse_ServingStatus
+	watchedAt  time.Time
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithReadinessPoller serves cached results from poller instead of running checks per call.
+// The poller's checkers replace the checkers passed to NewServer.
+func WithReadinessPoller(poller *vital.ReadinessPoller) Option {
+	return func(s *Server) { s.poller = poller }
+}
+
+// WithReadyFunc gates the overall status on readyFunc. While it returns false, the empty
+// service reports NOT_SERVING without running any checkers. Checker services are not gated.
+func WithReadyFunc(readyFunc func() bool) Option {
+	return func(s *Server) { s.readyFunc = readyFunc }
+}
+
+// WithLifecycle gates the overall status on lifecycle, so gRPC clients stop routing to
+// the service while it drains, as the HTTP readiness endpoint does.
+// A nil lifecycle is silently ignored.
+func WithLifecycle(lifecycle *vital.Lifecycle) Option {
+	if lifecycle == nil {
+		return func(*Server) {}
+	}
+
+	return WithReadyFunc(lifecycle.Ready)
+}
+
+// WithTimeout sets the maximum time allowed for all checks of one call to complete.
+// The default is 2 seconds. A value less than or equal to zero disables the timeout.
+func WithTimeout(d time.Duration) Option {
+	return func(s *Server) { s.timeout = d }
+}
+
+// WithWatchInterval sets how often Watch re-evaluates the status. The checks run at most
+// once per interval, however many streams are open. The default is 5 seconds.
+func WithWatchInterval(d time.Duration) Option {
+	return func(s *Server) { s.watchInterval = d }
+}
+
+// NewServer creates a Server for checkers. Register it with
+// healthpb.RegisterHealthServer(grpcServer, server).
+// It returns an error wrapping ErrDuplicateChecker when two checkers share a name.
+func NewServer(checkers []vital.Checker, opts ...Option) (*Server, error) {
+	checkersByName := make(map[string]vital.Checker, len(checkers))
+
+	for _, checker := range checkers {
+		if _, exists := checkersByName[checker.Name()]; exists {
+			return nil, fmt.Errorf("%w: %q", ErrDuplicateChecker, checker.Name())
+		}
+
+		checkersByName[checker.Name()] = checker
+	}
+
+	//nolint:exhaustruct // Poller, ready function, and watch state are optional
+	server := &Server{
+		checkers:       checkers,
+		checkersByName: checkersByName,
+		timeout:        defaultTimeout,
+		watchInterval:  defaultWatchInterval,
+	}
+
+	for _, o := range opts {
+		o(server)
+	}
+
+	if server.watchInterval <= 0 {
+		server.watchInterval = defaultWatchInterval
+	}
+
+	return server, nil
+}
+
+// Check returns the status of the requested service, or a NotFound error for unknown services.
+func (s *Server) Check(
+	ctx context.Context,
+	req *healthpb.HealthCheckRequest,
+) (*healthpb.HealthCheckResponse, error) {
+	servingStatus, ok := s.status(ctx, req.GetService())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.GetService())
+	}
+
+	return &healthpb.HealthCheckResponse{Status: servingStatus}, nil
+}
+
+// List returns the status of the overall service and of every checker.
+func (s *Server) List(ctx context.Context, _ *healthpb.HealthListRequest) (*healthpb.HealthListResponse, error) {
+	statuses := s.statuses(ctx)
+
+	response := &healthpb.HealthListResponse{
+		Statuses: make(map[string]*healthpb.HealthCheckResponse, len(statuses)),
+	}
+
+	for service, servingStatus := range statuses {
+		response.Statuses[service] = &healthpb.HealthCheckResponse{Status: servingStatus}
+	}
+
+	return response, nil
+}
+
+// Watch sends the status of the requested service, and again whenever it changes, until the
+// client cancels the stream. Unknown services are reported as SERVICE_UNKNOWN.
+// All streams share the results of one evaluation per watch interval.
+func (s *Server) Watch(
+	req *healthpb.HealthCheckRequest,
+	stream grpc.ServerStreamingServer[healthpb.HealthCheckResponse],
+) error {
+	ctx := stream.Context()
+
+	ticker := time.NewTicker(s.watchInterval)
+	defer ticker.Stop()
+
+	last := healthpb.HealthCheckResponse_ServingStatus(-1)
+
+	for {
+		servingStatus, ok := s.watchStatus(ctx, req.GetService())
+		if !ok {
+			servingStatus = healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+		}
+
+		if servingStatus != last {
+			err := stream.Send(&healthpb.HealthCheckResponse{Status: servingStatus})
+			if err != nil {
+				return fmt.Errorf("send health status: %w", err)
+			}
+
+			last = servingStatus
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// status returns the serving status of service. A named service runs only its checker, and
+// the overall status of a service that is not ready is reported without running the checks.
+func (s *Server) status(ctx context.Context, service string) (healthpb.HealthCheckResponse_ServingStatus, bool) {
+	if s.poller != nil {
+		servingStatus, ok := s.statuses(ctx)[service]
+
+		return servingStatus, ok
+	}
+
+	if service == "" {
+		if !s.ready() {
+			return healthpb.HealthCheckResponse_NOT_SERVING, true
+		}
+
+		overall, _ := s.run(ctx, s.checkers)
+
+		return servingStatus(overall), true
+	}
+
+	checker, ok := s.checkersByName[service]
+	if !ok {
+		return 0, false
+	}
+
+	_, checks := s.run(ctx, []vital.Checker{checker})
+
+	return servingStatus(checks[0].Status), true
+}
+
+// watchStatus returns the serving status of service from the evaluation shared by all
+// Watch streams.
+func (s *Server) watchStatus(ctx context.Context, service string) (healthpb.HealthCheckResponse_ServingStatus, bool) {
+	if service == "" && !s.ready() {
+		return healthpb.HealthCheckResponse_NOT_SERVING, true
+	}
+
+	servingStatus, ok := s.sharedEvaluation(ctx)[service]
+
+	return servingStatus, ok
+}
+
+// sharedEvaluation runs the checks at most once per watch interval and returns the serving
+// status per service name. The checks are not tied to the stream that triggers them, so a
+// canceled stream does not fail the result for the others.
+func (s *Server) sharedEvaluation(ctx context.Context) map[string]healthpb.HealthCheckResponse_ServingStatus {
+	if s.poller != nil {
+		return s.evaluate(ctx)
+	}
+
+	s.watchMutex.Lock()
+	defer s.watchMutex.Unlock()
+
+	if s.watched == nil || time.Since(s.watchedAt) >= s.watchInterval {
+		s.watched = s.evaluate(context.WithoutCancel(ctx))
+		s.watchedAt = time.Now()
+	}
+
+	return s.watched
+}
+
+// statuses runs the checks and returns the serving status per service name, with the
+// overall status gated on readiness.
+func (s *Server) statuses(ctx context.Context) map[string]healthpb.HealthCheckResponse_ServingStatus {
+	statuses := s.evaluate(ctx)
+
+	if !s.ready() {
+		statuses[""] = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+
+	return statuses
+}
+
+// evaluate runs the checks and returns the serving status per service name.
+func (s *Server) evaluate(ctx context.Context) map[string]healthpb.HealthCheckResponse_ServingStatus {
+	overall, checks := s.run(ctx, s.checkers)
+
+	statuses := make(map[string]healthpb.HealthCheckResponse_ServingStatus, len(checks)+1)
+	statuses[""] = servingStatus(overall)
+
+	for _, check := range checks {
+		statuses[check.Name] = servingStatus(check.Status)
+	}
+
+	return statuses
+}
+
+func (s *Server) ready() bool {
+	return s.readyFunc == nil || s.readyFunc()
+}
+
+// run returns the cached results of the poller if one is set, and runs checkers otherwise.
+func (s *Server) run(ctx context.Context, checkers []vital.Checker) (vital.Status, []vital.CheckResponse) {
+	if s.poller != nil {
+		return s.poller.Status(ctx)
+	}
+
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	return vital.RunChecks(ctx, checkers)
+}
+
+func servingStatus(checkStatus vital.Status) healthpb.HealthCheckResponse_ServingStatus {
+	if checkStatus == vital.StatusError {
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	}
+
+	return healthpb.HealthCheckResponse_SERVING
+}
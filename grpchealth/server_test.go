@@ -0,0 +1,334 @@
+package grpchealth_test
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/monkescience/testastic"
+	"github.com/monkescience/vital"
+	"github.com/monkescience/vital/grpchealth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func staticChecker(name string, checkStatus vital.Status) vital.Checker {
+	return vital.NewChecker(name, func(_ context.Context) (vital.Status, string) {
+		return checkStatus, ""
+	})
+}
+
+func newServer(tb testing.TB, checkers []vital.Checker, opts ...grpchealth.Option) *grpchealth.Server {
+	tb.Helper()
+
+	server, err := grpchealth.NewServer(checkers, opts...)
+	testastic.NoError(tb, err)
+
+	return server
+}
+
+func countingChecker(name string, calls *atomic.Int32) vital.Checker {
+	return vital.NewChecker(name, func(_ context.Context) (vital.Status, string) {
+		calls.Add(1)
+
+		return vital.StatusOK, ""
+	})
+}
+
+func TestNewServer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rejects duplicate checker names", func(t *testing.T) {
+		t.Parallel()
+
+		// given: two checkers with the same name
+		checkers := []vital.Checker{
+			staticChecker("database", vital.StatusOK),
+			staticChecker("database", vital.StatusError),
+		}
+
+		// when: creating a server
+		_, err := grpchealth.NewServer(checkers)
+
+		// then: it should report the duplicate
+		testastic.ErrorIs(t, err, grpchealth.ErrDuplicateChecker)
+	})
+}
+
+func TestServerCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		checkers []vital.Checker
+		service  string
+		want     healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{
+			"overall serving",
+			[]vital.Checker{staticChecker("database", vital.StatusOK)},
+			"",
+			healthpb.HealthCheckResponse_SERVING,
+		},
+		{
+			"overall not serving",
+			[]vital.Checker{staticChecker("database", vital.StatusOK), staticChecker("cache", vital.StatusError)},
+			"",
+			healthpb.HealthCheckResponse_NOT_SERVING,
+		},
+		{
+			"degraded is serving",
+			[]vital.Checker{vital.ConfigureChecker(staticChecker("cache", vital.StatusError), vital.WithInformational())},
+			"",
+			healthpb.HealthCheckResponse_SERVING,
+		},
+		{
+			"checker service",
+			[]vital.Checker{staticChecker("database", vital.StatusOK), staticChecker("cache", vital.StatusError)},
+			"cache",
+			healthpb.HealthCheckResponse_NOT_SERVING,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given: a health server for the checkers
+			server := newServer(t, tt.checkers)
+
+			// when: checking the service
+			resp, err := server.Check(t.Context(), &healthpb.HealthCheckRequest{Service: tt.service})
+
+			// then: it should report the serving status
+			testastic.NoError(t, err)
+			testastic.Equal(t, tt.want, resp.GetStatus())
+		})
+	}
+
+	t.Run("unknown service", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a health server without a checker named payments
+		server := newServer(t, []vital.Checker{staticChecker("database", vital.StatusOK)})
+
+		// when: checking the unknown service
+		_, err := server.Check(t.Context(), &healthpb.HealthCheckRequest{Service: "payments"})
+
+		// then: it should report not found
+		testastic.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("runs only the requested checker", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a health server with two checkers
+		var databaseCalls, cacheCalls atomic.Int32
+
+		server := newServer(t, []vital.Checker{
+			countingChecker("database", &databaseCalls),
+			countingChecker("cache", &cacheCalls),
+		})
+
+		// when: checking the database service
+		resp, err := server.Check(t.Context(), &healthpb.HealthCheckRequest{Service: "database"})
+
+		// then: only the database checker should have run
+		testastic.NoError(t, err)
+		testastic.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+		testastic.Equal(t, int32(1), databaseCalls.Load())
+		testastic.Equal(t, int32(0), cacheCalls.Load())
+	})
+
+	t.Run("not serving while lifecycle is draining", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a health server gated on a lifecycle that is shutting down
+		var calls atomic.Int32
+
+		checker := vital.NewChecker("database", func(_ context.Context) (vital.Status, string) {
+			calls.Add(1)
+
+			return vital.StatusOK, ""
+		})
+
+		lifecycle := vital.NewLifecycle()
+		lifecycle.SetReady()
+		_ = lifecycle.Shutdown(t.Context())
+
+		server := newServer(t, []vital.Checker{checker}, grpchealth.WithLifecycle(lifecycle))
+
+		// when: checking the overall service
+		resp, err := server.Check(t.Context(), &healthpb.HealthCheckRequest{})
+
+		// then: it should report not serving without running the checker
+		testastic.NoError(t, err)
+		testastic.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.GetStatus())
+		testastic.Equal(t, int32(0), calls.Load())
+	})
+
+	t.Run("ignores a nil lifecycle", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a health server with a nil lifecycle
+		server := newServer(t, []vital.Checker{staticChecker("database", vital.StatusOK)}, grpchealth.WithLifecycle(nil))
+
+		// when: checking the overall service
+		resp, err := server.Check(t.Context(), &healthpb.HealthCheckRequest{})
+
+		// then: it should report serving as without a lifecycle
+		testastic.NoError(t, err)
+		testastic.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+	})
+
+	t.Run("uses readiness poller", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a health server backed by a poller
+		poller := vital.NewReadinessPoller([]vital.Checker{staticChecker("database", vital.StatusError)})
+		server := newServer(t, nil, grpchealth.WithReadinessPoller(poller))
+
+		// when: checking the poller's checker
+		resp, err := server.Check(t.Context(), &healthpb.HealthCheckRequest{Service: "database"})
+
+		// then: it should report the poller's result
+		testastic.NoError(t, err)
+		testastic.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.GetStatus())
+	})
+}
+
+func TestServerList(t *testing.T) {
+	t.Parallel()
+
+	t.Run("lists overall and checker services", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a health server with a healthy and a failing checker
+		server := newServer(t, []vital.Checker{
+			staticChecker("database", vital.StatusOK),
+			staticChecker("cache", vital.StatusError),
+		})
+
+		// when: listing the services
+		resp, err := server.List(t.Context(), &healthpb.HealthListRequest{})
+
+		// then: every service should be listed with its status
+		testastic.NoError(t, err)
+		testastic.Len(t, resp.GetStatuses(), 3)
+		testastic.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.GetStatuses()[""].GetStatus())
+		testastic.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatuses()["database"].GetStatus())
+		testastic.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.GetStatuses()["cache"].GetStatus())
+	})
+}
+
+func TestServerWatch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("streams status changes", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a health server served over gRPC with a checker that can fail
+		var failing atomic.Bool
+
+		checker := vital.NewChecker("database", func(_ context.Context) (vital.Status, string) {
+			if failing.Load() {
+				return vital.StatusError, ""
+			}
+
+			return vital.StatusOK, ""
+		})
+
+		client := newHealthClient(t, newServer(t,
+			[]vital.Checker{checker},
+			grpchealth.WithWatchInterval(10*time.Millisecond),
+		))
+
+		// when: watching the overall service while the checker starts failing
+		stream, err := client.Watch(t.Context(), &healthpb.HealthCheckRequest{})
+		testastic.NoError(t, err)
+
+		first, err := stream.Recv()
+		testastic.NoError(t, err)
+
+		failing.Store(true)
+
+		second, err := stream.Recv()
+		testastic.NoError(t, err)
+
+		// then: both statuses should be streamed
+		testastic.Equal(t, healthpb.HealthCheckResponse_SERVING, first.GetStatus())
+		testastic.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, second.GetStatus())
+	})
+
+	t.Run("shares one evaluation across streams", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a health server served over gRPC with a long watch interval
+		var calls atomic.Int32
+
+		client := newHealthClient(t, newServer(t,
+			[]vital.Checker{countingChecker("database", &calls)},
+			grpchealth.WithWatchInterval(time.Minute),
+		))
+
+		// when: two clients watch the service
+		for range 2 {
+			stream, err := client.Watch(t.Context(), &healthpb.HealthCheckRequest{})
+			testastic.NoError(t, err)
+
+			_, err = stream.Recv()
+			testastic.NoError(t, err)
+		}
+
+		// then: the checker should have run once
+		testastic.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("reports unknown services", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a health server served over gRPC
+		client := newHealthClient(t, newServer(t, nil))
+
+		// when: watching an unknown service
+		stream, err := client.Watch(t.Context(), &healthpb.HealthCheckRequest{Service: "payments"})
+		testastic.NoError(t, err)
+
+		resp, err := stream.Recv()
+
+		// then: it should report the service as unknown
+		testastic.NoError(t, err)
+		testastic.Equal(t, healthpb.HealthCheckResponse_SERVICE_UNKNOWN, resp.GetStatus())
+	})
+}
+
+func newHealthClient(tb testing.TB, server healthpb.HealthServer) healthpb.HealthClient {
+	tb.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, server)
+
+	go func() { _ = grpcServer.Serve(listener) }()
+
+	tb.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	testastic.NoError(tb, err)
+
+	tb.Cleanup(func() { _ = conn.Close() })
+
+	return healthpb.NewHealthClient(conn)
+}
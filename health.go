@@ -6,7 +6,18 @@
 	"fmt"
 	"log/slog"
 	"net/http"
+	"slices"
+	"sync"
 	"time"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	defaultReadyTimeout = 2 * time.Second
+	livePath            = "/livez"
+	startedPath         = "/startupz"
+	readyPath           = "/readyz"
 )
 
 // Status represents the health status of a service or check.
@@ -17,11 +28,15 @@
 	StatusOK Status = "ok"
 	// StatusError indicates the service or check has failed.
 	StatusError Status = "error"
+	// StatusDegraded indicates the service is serving but an informational check has failed.
+	StatusDegraded Status = "degraded"
 )
 
 // LiveResponse represents the response payload for the liveness health check endpoint.
 type LiveResponse struct {
-	Status Status `json:"status"`
+	Status Status     `json:"status"`
+	Build  *BuildInfo `json:"build,omitempty"`
+	Uptime string     `json:"uptime,omitempty"`
 }
 
 // ReadyResponse represents the response payload for the readiness health check endpoint.
@@ -30,14 +45,17 @@ type ReadyResponse struct {
 	Checks      []CheckResponse `json:"checks"`
 	Version     string          `json:"version,omitempty"`
 	Environment string          `json:"environment,omitempty"`
+	Build       *BuildInfo      `json:"build,omitempty"`
+	Uptime      string          `json:"uptime,omitempty"`
 }
 
 // CheckResponse represents the result of a single health check.
 type CheckResponse struct {
-	Name     string `json:"name"`
-	Status   Status `json:"status"`
-	Message  string `json:"message,omitempty"`
-	Duration string `json:"duration,omitempty"`
+	Name     string          `json:"name"`
+	Status   Status          `json:"status"`
+	Message  string          `json:"message,omitempty"`
+	Duration string          `json:"duration,omitempty"`
+	Checks   []CheckResponse `json:"checks,omitempty"`
 }
 
 // Checker performs a health check and returns a status and optional message.
@@ -47,8 +65,59 @@ type Checker interface {
 	Check(ctx context.Context) (Status, string)
 }
 
+// CheckOption configures how a single checker is run during readiness checks.
+type CheckOption func(*configuredChecker)
+
+// WithCheckTimeout bounds the duration of a single checker. The checker's context is
+// canceled after d, in addition to the overall readiness timeout. A value less than or
+// equal to zero leaves the checker bounded only by the overall timeout.
+func WithCheckTimeout(d time.Duration) CheckOption {
+	return func(c *configuredChecker) { c.timeout = d }
+}
+
+// WithInformational marks a checker as non-critical. A failing informational checker
+// reports the service as degraded but does not fail readiness with 503.
+func WithInformational() CheckOption {
+	return func(c *configuredChecker) { c.informational = true }
+}
+
+// ConfigureChecker wraps checker with per-check options such as a timeout or criticality.
+// Checkers are critical and use only the overall readiness timeout by default.
+func ConfigureChecker(checker Checker, opts ...CheckOption) Checker {
+	configured := &configuredChecker{Checker: checker}
+	for _, o := range opts {
+		o(configured)
+	}
+
+	return configured
+}
+
+type configuredChecker struct {
+	Checker
+
+	timeout       time.Duration
+	informational bool
+}
+
+func (c *configuredChecker) Check(ctx context.Context) (Status, string) {
+	status, msg, _ := checkWithMembers(ctx, c)
+
+	return status, msg
+}
+
+func isInformational(chk Checker) bool {
+	configured, ok := chk.(*configuredChecker)
+
+	return ok && configured.informational
+}
+
 type readyConfig struct {
 	overallTimeout time.Duration
+	poller         *ReadinessPoller
+	readyFunc      func() bool
+	healthJSON     bool
+	info           responseInfo
+	metrics        *checkMetrics
 }
 
 type checkResult struct {
@@ -60,25 +129,30 @@ func runCheck(ctx context.Context, chk Checker) CheckResponse {
 	start := time.Now()
 	checkerName := chk.Name()
 
-	status, msg := chk.Check(ctx)
-
-	err := ctx.Err()
-	if err != nil && status == StatusOK {
-		status = StatusError
-
-		if msg == "" {
-			msg = err.Error()
-		} else {
-			msg = msg + "; " + err.Error()
-		}
-	}
+	status, msg, checks := checkWithMembers(ctx, chk)
+	status, msg = statusWithContextErr(ctx, status, msg)
 
 	return CheckResponse{
 		Name:     checkerName,
 		Status:   status,
 		Message:  msg,
 		Duration: time.Since(start).String(),
+		Checks:   checks,
+	}
+}
+
+// statusWithContextErr turns an OK status into an error when ctx expired during the check.
+func statusWithContextErr(ctx context.Context, status Status, msg string) (Status, string) {
+	err := ctx.Err()
+	if err == nil || status != StatusOK {
+		return status, msg
+	}
+
+	if msg == "" {
+		return StatusError, err.Error()
 	}
+
+	return StatusError, msg + "; " + err.Error()
 }
 
 // ReadyOption configures the readiness handler behavior.
@@ -91,12 +165,41 @@ func WithOverallReadyTimeout(d time.Duration) ReadyOption {
 	return func(c *readyConfig) { c.overallTimeout = d }
 }
 
+// WithReadyFunc gates readiness on readyFunc. While it returns false, the readiness
+// endpoint responds with 503 without running any checkers.
+func WithReadyFunc(readyFunc func() bool) ReadyOption {
+	return func(c *readyConfig) { c.readyFunc = readyFunc }
+}
+
+// WithHealthJSONFormat responds in the IETF health check draft format, served as
+// application/health+json with pass, warn, and fail status values, instead of ReadyResponse.
+func WithHealthJSONFormat() ReadyOption {
+	return func(c *readyConfig) { c.healthJSON = true }
+}
+
+// WithCheckMetrics records the health.check.status gauge and health.check.duration
+// histogram for every check run by the readiness handler, and logs changes of the readiness
+// status with a span event on the request span. A nil provider uses the global meter
+// provider. With WithReadinessPoller, configure the metrics on the poller with
+// WithPollMetrics instead; readiness changes are still reported by the handler.
+func WithCheckMetrics(provider metric.MeterProvider) ReadyOption {
+	return func(c *readyConfig) { c.metrics = newCheckMetrics(provider) }
+}
+
+// WithReadinessPoller serves cached results from poller instead of running checks per request.
+// The poller's checkers replace the checkers passed to the readiness handler, and the
+// overall timeout is taken from the poller.
+func WithReadinessPoller(poller *ReadinessPoller) ReadyOption {
+	return func(c *readyConfig) { c.poller = poller }
+}
+
 type handlerConfig struct {
 	version     string
 	environment string
 	startedFunc func() bool
 	checkers    []Checker
 	readyOpts   []ReadyOption
+	info        responseInfo
 }
 
 // HealthHandlerOption configures the health check handler.
@@ -112,6 +215,17 @@ func WithEnvironment(env string) HealthHandlerOption {
 	return func(c *handlerConfig) { c.environment = env }
 }
 
+// WithBuildInfo includes the build information from ReadBuildInfo in all health responses
+// and serves it at /version.
+func WithBuildInfo() HealthHandlerOption {
+	return func(c *handlerConfig) { c.info.build = true }
+}
+
+// WithUptime includes the process uptime in all health responses.
+func WithUptime() HealthHandlerOption {
+	return func(c *handlerConfig) { c.info.uptime = true }
+}
+
 // WithCheckers adds health checkers to be executed during readiness checks.
 func WithCheckers(checkers ...Checker) HealthHandlerOption {
 	return func(c *handlerConfig) { c.checkers = append(c.checkers, checkers...) }
@@ -122,45 +236,91 @@ func WithStartedFunc(startedFunc func() bool) HealthHandlerOption {
 	return func(c *handlerConfig) { c.startedFunc = startedFunc }
 }
 
+// WithLifecycle drives /startupz and /readyz from lifecycle: startup succeeds once the
+// lifecycle has left the starting state, and readiness fails unless it is ready.
+// A nil lifecycle is silently ignored.
+func WithLifecycle(lifecycle *Lifecycle) HealthHandlerOption {
+	return func(c *handlerConfig) {
+		if lifecycle == nil {
+			return
+		}
+
+		c.startedFunc = lifecycle.Started
+		c.readyOpts = append(c.readyOpts, WithReadyFunc(lifecycle.Ready))
+	}
+}
+
 // WithReadyOptions configures readiness-specific options such as timeouts.
 func WithReadyOptions(opts ...ReadyOption) HealthHandlerOption {
 	return func(c *handlerConfig) { c.readyOpts = append(c.readyOpts, opts...) }
 }
 
 // NewHealthHandler creates an HTTP handler that provides health check endpoints
-// at /livez, /startupz, and /readyz.
+// at /livez, /startupz, and /readyz, and /version when WithBuildInfo is set.
 func NewHealthHandler(opts ...HealthHandlerOption) http.Handler {
+	return newHandlerConfig(opts...).handler()
+}
+
+func newHandlerConfig(opts ...HealthHandlerOption) handlerConfig {
 	var handlerCfg handlerConfig
 	for _, o := range opts {
 		o(&handlerCfg)
 	}
 
+	return handlerCfg
+}
+
+// paths returns the endpoint paths served by the health handler.
+func (handlerCfg handlerConfig) paths() []string {
+	paths := []string{livePath, startedPath, readyPath}
+	if handlerCfg.info.build {
+		paths = append(paths, versionPath)
+	}
+
+	return paths
+}
+
+func (handlerCfg handlerConfig) handler() http.Handler {
+	readyOpts := append(slices.Clone(handlerCfg.readyOpts), func(c *readyConfig) { c.info = handlerCfg.info })
+
 	mux := http.NewServeMux()
 
-	mux.HandleFunc("GET /livez", LiveHandlerFunc())
-	mux.HandleFunc("GET /startupz", StartedHandlerFunc(handlerCfg.startedFunc))
+	mux.HandleFunc("GET "+livePath, liveHandlerFunc(handlerCfg.info))
+	mux.HandleFunc("GET "+startedPath, startedHandlerFunc(handlerCfg.startedFunc, handlerCfg.info))
 	mux.HandleFunc(
-		"GET /readyz",
-		ReadyHandlerFunc(handlerCfg.version, handlerCfg.environment, handlerCfg.checkers, handlerCfg.readyOpts...),
+		"GET "+readyPath,
+		ReadyHandlerFunc(handlerCfg.version, handlerCfg.environment, handlerCfg.checkers, readyOpts...),
 	)
 
+	if handlerCfg.info.build {
+		mux.HandleFunc("GET "+versionPath, VersionHandlerFunc())
+	}
+
 	return mux
 }
 
 // LiveHandlerFunc returns an HTTP handler function for liveness health checks.
 func LiveHandlerFunc() http.HandlerFunc {
+	return liveHandlerFunc(responseInfo{})
+}
+
+func liveHandlerFunc(info responseInfo) http.HandlerFunc {
 	return func(writer http.ResponseWriter, req *http.Request) {
-		response := LiveResponse{Status: StatusOK}
+		response := LiveResponse{Status: StatusOK, Build: info.buildInfo(), Uptime: info.uptimeString()}
 
-		disableResponseCacheHeaders(writer)
+		NoCache(writer)
 		respondJSON(req.Context(), writer, http.StatusOK, response)
 	}
 }
 
 // StartedHandlerFunc returns an HTTP handler function for startup health checks.
 func StartedHandlerFunc(startedFunc func() bool) http.HandlerFunc {
+	return startedHandlerFunc(startedFunc, responseInfo{})
+}
+
+func startedHandlerFunc(startedFunc func() bool, info responseInfo) http.HandlerFunc {
 	return func(writer http.ResponseWriter, req *http.Request) {
-		response := LiveResponse{Status: StatusOK}
+		response := LiveResponse{Status: StatusOK, Build: info.buildInfo(), Uptime: info.uptimeString()}
 		statusCode := http.StatusOK
 
 		if startedFunc != nil && !startedFunc() {
@@ -168,7 +328,7 @@ func StartedHandlerFunc(startedFunc func() bool) http.HandlerFunc {
 			statusCode = http.StatusServiceUnavailable
 		}
 
-		disableResponseCacheHeaders(writer)
+		NoCache(writer)
 		respondJSON(req.Context(), writer, statusCode, response)
 	}
 }
@@ -181,12 +341,8 @@ func ReadyHandlerFunc(
 	checkers []Checker,
 	opts ...ReadyOption,
 ) http.HandlerFunc {
-	const (
-		defaultOverallTimeout = 2 * time.Second
-	)
-
 	cfg := readyConfig{
-		overallTimeout: defaultOverallTimeout,
+		overallTimeout: defaultReadyTimeout,
 	}
 
 	for _, o := range opts {
@@ -205,28 +361,61 @@ func readyHandler(
 	version, environment string,
 	checkers []Checker,
 ) {
-	checkCtx, cancel := contextWithTimeoutIfNeeded(req.Context(), cfg.overallTimeout)
-	if cancel != nil {
-		defer cancel()
-	}
+	var checks []CheckResponse
+
+	gateOpen := cfg.readyFunc == nil || cfg.readyFunc()
+
+	switch {
+	case !gateOpen:
+		checkers = nil
+		checks = []CheckResponse{}
+	case cfg.poller != nil:
+		checkers = cfg.poller.checkers
+		checks = cfg.poller.Checks(req.Context())
+	default:
+		checkCtx, cancel := contextWithTimeoutIfNeeded(req.Context(), cfg.overallTimeout)
+		if cancel != nil {
+			defer cancel()
+		}
 
-	checks := runAllChecks(checkCtx, checkers)
+		checks = runAllChecks(checkCtx, checkers)
+
+		if cfg.metrics != nil {
+			cfg.metrics.recordChecks(req.Context(), checks)
+		}
+	}
 
 	response := ReadyResponse{
 		Status:      StatusOK,
 		Checks:      checks,
 		Version:     version,
 		Environment: environment,
+		Build:       cfg.info.buildInfo(),
+		Uptime:      cfg.info.uptimeString(),
 	}
 
-	response.Status = overallStatus(checks)
+	response.Status = overallStatus(checkers, checks)
+	if !gateOpen {
+		response.Status = StatusError
+	}
+
+	if cfg.metrics != nil {
+		cfg.metrics.recordReadiness(req.Context(), response.Status)
+	}
 
 	statusCode := http.StatusOK
-	if response.Status != StatusOK {
+	if response.Status == StatusError {
 		statusCode = http.StatusServiceUnavailable
 	}
 
-	disableResponseCacheHeaders(writer)
+	NoCache(writer)
+
+	if cfg.healthJSON {
+		respondJSONAs(req.Context(), writer, healthJSONContentType, statusCode, newHealthJSONResponse(response, checkers))
+
+		return
+	}
+
 	respondJSON(req.Context(), writer, statusCode, response)
 }
 
@@ -241,6 +430,14 @@ func contextWithTimeoutIfNeeded(
 	return context.WithTimeout(ctx, duration)
 }
 
+// RunChecks runs checkers concurrently with panic isolation, as the readiness endpoint does,
+// and returns the overall status and the results in checker order. ctx bounds all checks.
+func RunChecks(ctx context.Context, checkers []Checker) (Status, []CheckResponse) {
+	checks := runAllChecks(ctx, checkers)
+
+	return overallStatus(checkers, checks), checks
+}
+
 func runAllChecks(ctx context.Context, checkers []Checker) []CheckResponse {
 	responses := make([]CheckResponse, len(checkers))
 	if len(checkers) == 0 {
@@ -257,20 +454,46 @@ func runAllChecks(ctx context.Context, checkers []Checker) []CheckResponse {
 	return collectCheckResponses(ctx, checkers, responses, results, startedAt)
 }
 
+// checkTrackerKey is the context key of the WaitGroup that tracks check goroutines.
+type checkTrackerKey struct{}
+
+// withCheckTracker makes every check goroutine started with ctx, including those of nested
+// checker groups, register with tracker, so callers can wait for checks that outlive
+// runAllChecks after ctx expired.
+func withCheckTracker(ctx context.Context, tracker *sync.WaitGroup) context.Context {
+	return context.WithValue(ctx, checkTrackerKey{}, tracker)
+}
+
 func startCheckWorker(
 	ctx context.Context,
 	results chan<- checkResult,
 	checkerIndex int,
 	checker Checker,
 ) {
+	tracker, _ := ctx.Value(checkTrackerKey{}).(*sync.WaitGroup)
+	if tracker != nil {
+		tracker.Add(1)
+	}
+
 	go func() {
 		checkStartedAt := time.Now()
 		response := CheckResponse{}
 
 		defer func() {
+			if tracker != nil {
+				defer tracker.Done()
+			}
+
 			if recovered := recover(); recovered != nil {
+				name := checkerName(checker)
+
+				reportPanic(
+					ctx, slog.Default(), componentHealthChecker, "health checker panicked", recovered,
+					slog.String("checker", name),
+				)
+
 				response = CheckResponse{
-					Name:     checkerName(checker),
+					Name:     name,
 					Status:   StatusError,
 					Message:  fmt.Sprintf("panic: %v", recovered),
 					Duration: time.Since(checkStartedAt).String(),
@@ -348,14 +571,22 @@ func() {
 	return name
 }
 
-func overallStatus(checks []CheckResponse) Status {
-	for _, c := range checks {
-		if c.Status != StatusOK {
+func overallStatus(checkers []Checker, checks []CheckResponse) Status {
+	status := StatusOK
+
+	for idx, c := range checks {
+		if c.Status == StatusOK {
+			continue
+		}
+
+		if c.Status != StatusDegraded && !isInformational(checkers[idx]) {
 			return StatusError
 		}
+
+		status = StatusDegraded
 	}
 
-	return StatusOK
+	return status
 }
 
 func respondJSON(
@@ -363,30 +594,33 @@ func respondJSON(
 	writer http.ResponseWriter,
 	statusCode int,
 	payload any,
+) {
+	respondJSONAs(ctx, writer, "application/json", statusCode, payload)
+}
+
+func respondJSONAs(
+	ctx context.Context,
+	writer http.ResponseWriter,
+	contentType string,
+	statusCode int,
+	payload any,
 ) {
 	body, err := json.Marshal(payload)
 	if err == nil {
 		body = append(body, '\n')
 
-		writeErr := writeJSONBytes(writer, "application/json", statusCode, body)
+		writeErr := writeJSONBytes(writer, contentType, statusCode, body)
 		if writeErr != nil {
-			slog.ErrorContext(ctx, "failed to write JSON response", slog.Any("error", writeErr))
+			slog.ErrorContext(ctx, "failed to write JSON response", ErrAttr(writeErr))
 		}
 
 		return
 	}
 
-	slog.ErrorContext(ctx, "failed to encode JSON response", slog.Any("error", err))
+	slog.ErrorContext(ctx, "failed to encode JSON response", ErrAttr(err))
 
 	fallbackErr := writeJSONBytes(writer, "application/json", http.StatusInternalServerError, []byte(fallbackJSONResponse))
 	if fallbackErr != nil {
-		slog.ErrorContext(ctx, "failed to write fallback JSON response", slog.Any("error", fallbackErr))
+		slog.ErrorContext(ctx, "failed to write fallback JSON response", ErrAttr(fallbackErr))
 	}
 }
-
-// disableResponseCacheHeaders sets headers to prevent caching of health responses.
-func disableResponseCacheHeaders(writer http.ResponseWriter) {
-	writer.Header().Set("Cache-Control", "no-store, no-cache")
-	writer.Header().Set("Pragma", "no-cache")
-	writer.Header().Set("Expires", "Thu, 01 Jan 1970 00:00:00 GMT")
-}
@@ -677,3 +677,127 @@ func TestReadyHandler(t *testing.T) {
 		testastic.Equal(t, "production", response.Environment)
 	})
 }
+
+func TestReadyHandler_CheckOptions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("failing informational checker reports degraded", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a critical healthy checker and a failing informational checker
+		database := &mockChecker{name: "database", status: vital.StatusOK}
+		cache := &mockChecker{name: "cache", status: vital.StatusError, message: "connection refused"}
+
+		handlers := vital.NewHealthHandler(
+			vital.WithCheckers(
+				database,
+				vital.ConfigureChecker(cache, vital.WithInformational()),
+			),
+		)
+		responseRecorder := httptest.NewRecorder()
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/readyz", nil)
+
+		// when: calling the ready endpoint
+		handlers.ServeHTTP(responseRecorder, req)
+
+		// then: it should stay in rotation and report degraded
+		testastic.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var response vital.ReadyResponse
+
+		err := json.NewDecoder(responseRecorder.Body).Decode(&response)
+		testastic.NoError(t, err)
+
+		testastic.Equal(t, vital.StatusDegraded, response.Status)
+		testastic.Equal(t, "cache", response.Checks[1].Name)
+		testastic.Equal(t, vital.StatusError, response.Checks[1].Status)
+	})
+
+	t.Run("failing critical checker still returns 503", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a failing critical checker alongside a failing informational checker
+		database := &mockChecker{name: "database", status: vital.StatusError}
+		cache := &mockChecker{name: "cache", status: vital.StatusError}
+
+		handlers := vital.NewHealthHandler(
+			vital.WithCheckers(
+				vital.ConfigureChecker(database),
+				vital.ConfigureChecker(cache, vital.WithInformational()),
+			),
+		)
+		responseRecorder := httptest.NewRecorder()
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/readyz", nil)
+
+		// when: calling the ready endpoint
+		handlers.ServeHTTP(responseRecorder, req)
+
+		// then: it should fail readiness
+		testastic.Equal(t, http.StatusServiceUnavailable, responseRecorder.Code)
+
+		var response vital.ReadyResponse
+
+		err := json.NewDecoder(responseRecorder.Body).Decode(&response)
+		testastic.NoError(t, err)
+
+		testastic.Equal(t, vital.StatusError, response.Status)
+	})
+
+	t.Run("per-check timeout fails only the slow checker", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a slow checker with a short per-check timeout and a fast checker
+		slow := &mockChecker{name: "slow", status: vital.StatusOK, delay: time.Second}
+		fast := &mockChecker{name: "fast", status: vital.StatusOK}
+
+		handlers := vital.NewHealthHandler(
+			vital.WithCheckers(
+				vital.ConfigureChecker(slow, vital.WithCheckTimeout(20*time.Millisecond)),
+				fast,
+			),
+			vital.WithReadyOptions(vital.WithOverallReadyTimeout(5*time.Second)),
+		)
+		responseRecorder := httptest.NewRecorder()
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/readyz", nil)
+
+		// when: calling the ready endpoint
+		start := time.Now()
+
+		handlers.ServeHTTP(responseRecorder, req)
+
+		// then: the slow checker should fail well before the overall timeout
+		testastic.Less(t, time.Since(start), time.Second)
+		testastic.Equal(t, http.StatusServiceUnavailable, responseRecorder.Code)
+
+		var response vital.ReadyResponse
+
+		err := json.NewDecoder(responseRecorder.Body).Decode(&response)
+		testastic.NoError(t, err)
+
+		testastic.Equal(t, vital.StatusError, response.Checks[0].Status)
+		testastic.Equal(t, vital.StatusOK, response.Checks[1].Status)
+	})
+}
+
+func TestRunChecks(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns overall status and results", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a healthy checker and a failing informational checker
+		checkers := []vital.Checker{
+			&mockChecker{name: "database", status: vital.StatusOK},
+			vital.ConfigureChecker(&mockChecker{name: "cache", status: vital.StatusError}, vital.WithInformational()),
+		}
+
+		// when: running the checks
+		status, checks := vital.RunChecks(t.Context(), checkers)
+
+		// then: the service should be degraded with results in checker order
+		testastic.Equal(t, vital.StatusDegraded, status)
+		testastic.Len(t, checks, 2)
+		testastic.Equal(t, "database", checks[0].Name)
+		testastic.Equal(t, vital.StatusError, checks[1].Status)
+	})
+}
@@ -0,0 +1,86 @@
+package vital
+
+import (
+	"time"
+)
+
+const (
+	healthJSONContentType = "application/health+json"
+	healthJSONPass        = "pass"
+	healthJSONWarn        = "warn"
+	healthJSONFail        = "fail"
+	observedUnitMillis    = "ms"
+)
+
+// ComponentTyper is an optional interface for checkers that report a component type,
+// such as "datastore" or "system", in the health check draft format.
+type ComponentTyper interface {
+	ComponentType() string
+}
+
+// HealthJSONResponse is the readiness payload in the IETF health check draft format
+// (application/health+json).
+type HealthJSONResponse struct {
+	Status  string                       `json:"status"`
+	Version string                       `json:"version,omitempty"`
+	Checks  map[string][]HealthJSONCheck `json:"checks,omitempty"`
+}
+
+// HealthJSONCheck is the result of a single check in the health check draft format.
+// ObservedValue holds the check duration in milliseconds.
+type HealthJSONCheck struct {
+	ComponentType string  `json:"componentType,omitempty"`
+	ObservedValue float64 `json:"observedValue"`
+	ObservedUnit  string  `json:"observedUnit"`
+	Status        string  `json:"status"`
+	Output        string  `json:"output,omitempty"`
+	Time          string  `json:"time"`
+}
+
+func newHealthJSONResponse(response ReadyResponse, checkers []Checker) HealthJSONResponse {
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	checks := make(map[string][]HealthJSONCheck, len(response.Checks))
+
+	for idx, check := range response.Checks {
+		duration, _ := time.ParseDuration(check.Duration)
+
+		checks[check.Name] = append(checks[check.Name], HealthJSONCheck{
+			ComponentType: checkerComponentType(checkers[idx]),
+			ObservedValue: float64(duration) / float64(time.Millisecond),
+			ObservedUnit:  observedUnitMillis,
+			Status:        healthJSONCheckStatus(check.Status, isInformational(checkers[idx])),
+			Output:        check.Message,
+			Time:          now,
+		})
+	}
+
+	return HealthJSONResponse{
+		Status:  healthJSONCheckStatus(response.Status, false),
+		Version: response.Version,
+		Checks:  checks,
+	}
+}
+
+func healthJSONCheckStatus(status Status, informational bool) string {
+	switch {
+	case status == StatusOK:
+		return healthJSONPass
+	case status == StatusDegraded || informational:
+		return healthJSONWarn
+	default:
+		return healthJSONFail
+	}
+}
+
+func checkerComponentType(chk Checker) string {
+	if configured, ok := chk.(*configuredChecker); ok {
+		chk = configured.Checker
+	}
+
+	if typer, ok := chk.(ComponentTyper); ok {
+		return typer.ComponentType()
+	}
+
+	return ""
+}
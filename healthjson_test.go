@@ -0,0 +1,96 @@
+package vital_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/monkescience/testastic"
+	"github.com/monkescience/vital"
+)
+
+// datastoreChecker reports a component type for the health check draft format.
+type datastoreChecker struct {
+	mockChecker
+}
+
+func (d *datastoreChecker) ComponentType() string {
+	return "datastore"
+}
+
+func TestReadyHandler_HealthJSONFormat(t *testing.T) {
+	t.Parallel()
+
+	t.Run("responds in health check draft format", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a ready handler in health+json format with a healthy datastore checker
+		database := &datastoreChecker{mockChecker{name: "database", status: vital.StatusOK, message: "connected"}}
+
+		handlers := vital.NewHealthHandler(
+			vital.WithVersion("1.0.0"),
+			vital.WithCheckers(database),
+			vital.WithReadyOptions(vital.WithHealthJSONFormat()),
+		)
+		responseRecorder := httptest.NewRecorder()
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/readyz", nil)
+
+		// when: calling the ready endpoint
+		handlers.ServeHTTP(responseRecorder, req)
+
+		// then: it should return a passing health+json document
+		testastic.Equal(t, http.StatusOK, responseRecorder.Code)
+		testastic.Equal(t, "application/health+json", responseRecorder.Header().Get("Content-Type"))
+
+		var response vital.HealthJSONResponse
+
+		err := json.NewDecoder(responseRecorder.Body).Decode(&response)
+		testastic.NoError(t, err)
+
+		testastic.Equal(t, "pass", response.Status)
+		testastic.Equal(t, "1.0.0", response.Version)
+
+		checks := response.Checks["database"]
+		testastic.Equal(t, 1, len(checks))
+		testastic.Equal(t, "datastore", checks[0].ComponentType)
+		testastic.Equal(t, "pass", checks[0].Status)
+		testastic.Equal(t, "ms", checks[0].ObservedUnit)
+		testastic.Equal(t, "connected", checks[0].Output)
+	})
+
+	t.Run("maps informational and critical failures to warn and fail", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a failing informational checker and a failing critical checker
+		cache := &mockChecker{name: "cache", status: vital.StatusError}
+		database := &datastoreChecker{mockChecker{name: "database", status: vital.StatusError}}
+
+		handlers := vital.NewHealthHandler(
+			vital.WithCheckers(
+				vital.ConfigureChecker(cache, vital.WithInformational()),
+				vital.ConfigureChecker(database),
+			),
+			vital.WithReadyOptions(vital.WithHealthJSONFormat()),
+		)
+		responseRecorder := httptest.NewRecorder()
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/readyz", nil)
+
+		// when: calling the ready endpoint
+		handlers.ServeHTTP(responseRecorder, req)
+
+		// then: the overall status should fail with per-check warn and fail
+		testastic.Equal(t, http.StatusServiceUnavailable, responseRecorder.Code)
+
+		var response vital.HealthJSONResponse
+
+		err := json.NewDecoder(responseRecorder.Body).Decode(&response)
+		testastic.NoError(t, err)
+
+		testastic.Equal(t, "fail", response.Status)
+		testastic.Equal(t, "warn", response.Checks["cache"][0].Status)
+		testastic.Equal(t, "fail", response.Checks["database"][0].Status)
+		testastic.Equal(t, "datastore", response.Checks["database"][0].ComponentType)
+	})
+}
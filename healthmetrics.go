@@ -0,0 +1,107 @@
+package vital
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	meterName             = "github.com/monkescience/vital"
+	checkStatusMetric     = "health.check.status"
+	checkDurationMetric   = "health.check.duration"
+	checkNameAttr         = "health.check.name"
+	readinessChangedEvent = "health.readiness.changed"
+)
+
+// checkMetrics records health check results as OpenTelemetry metrics and reports changes
+// of the readiness status as log records and span events.
+type checkMetrics struct {
+	status   metric.Int64Gauge
+	duration metric.Float64Histogram
+
+	mutex sync.Mutex
+	last  Status
+}
+
+// newCheckMetrics creates the health check instruments with provider, or with the global
+// meter provider when provider is nil. Instrument errors are reported to otel.Handle.
+func newCheckMetrics(provider metric.MeterProvider) *checkMetrics {
+	if provider == nil {
+		provider = otel.GetMeterProvider()
+	}
+
+	meter := provider.Meter(meterName)
+
+	status, err := meter.Int64Gauge(
+		checkStatusMetric,
+		metric.WithDescription("Whether the health check passed (1) or failed (0)."),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	duration, err := meter.Float64Histogram(
+		checkDurationMetric,
+		metric.WithDescription("Duration of the health check."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	//nolint:exhaustruct // The last status is set by the first observation
+	return &checkMetrics{status: status, duration: duration}
+}
+
+// recordChecks records the status and duration of each check. Degraded checks count as passing.
+func (m *checkMetrics) recordChecks(ctx context.Context, checks []CheckResponse) {
+	for _, check := range checks {
+		attrs := metric.WithAttributes(attribute.String(checkNameAttr, check.Name))
+
+		passed := int64(0)
+		if check.Status != StatusError {
+			passed = 1
+		}
+
+		m.status.Record(ctx, passed, attrs)
+
+		if duration, err := time.ParseDuration(check.Duration); err == nil {
+			m.duration.Record(ctx, duration.Seconds(), attrs)
+		}
+	}
+}
+
+// recordReadiness logs and adds a span event when status differs from the last observed
+// readiness status. The first observation is not reported.
+func (m *checkMetrics) recordReadiness(ctx context.Context, status Status) {
+	m.mutex.Lock()
+	previous := m.last
+	m.last = status
+	m.mutex.Unlock()
+
+	if previous == "" || previous == status {
+		return
+	}
+
+	level := slog.LevelInfo
+	if status != StatusOK {
+		level = slog.LevelWarn
+	}
+
+	slog.Default().LogAttrs(ctx, level, "readiness changed",
+		slog.String("from", string(previous)),
+		slog.String("to", string(status)),
+	)
+
+	AddSpanEvent(ctx, readinessChangedEvent,
+		attribute.String("health.readiness.from", string(previous)),
+		attribute.String("health.readiness.to", string(status)),
+	)
+}
@@ -0,0 +1,206 @@
+package vital_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/monkescience/testastic"
+	"github.com/monkescience/vital"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+// recordingMeterProvider records the values of the counters, gauges, and histograms created
+// by its meter.
+type recordingMeterProvider struct {
+	noop.MeterProvider
+
+	mu           sync.Mutex
+	measurements []measurement
+}
+
+type measurement struct {
+	instrument string
+	check      string
+	component  string
+	value      float64
+}
+
+type recordingMeter struct {
+	noop.Meter
+
+	provider *recordingMeterProvider
+}
+
+type recordingInt64Gauge struct {
+	noop.Int64Gauge
+
+	name     string
+	provider *recordingMeterProvider
+}
+
+type recordingInt64Counter struct {
+	noop.Int64Counter
+
+	name     string
+	provider *recordingMeterProvider
+}
+
+type recordingFloat64Histogram struct {
+	noop.Float64Histogram
+
+	name     string
+	provider *recordingMeterProvider
+}
+
+func (p *recordingMeterProvider) Meter(string, ...metric.MeterOption) metric.Meter {
+	return &recordingMeter{provider: p}
+}
+
+func (p *recordingMeterProvider) record(instrument string, value float64, attrs attribute.Set) {
+	check, _ := attrs.Value("health.check.name")
+	component, _ := attrs.Value("vital.component")
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.measurements = append(p.measurements, measurement{
+		instrument: instrument,
+		check:      check.AsString(),
+		component:  component.AsString(),
+		value:      value,
+	})
+}
+
+func (p *recordingMeterProvider) recorded(instrument string) []measurement {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var measurements []measurement
+
+	for _, m := range p.measurements {
+		if m.instrument == instrument {
+			measurements = append(measurements, m)
+		}
+	}
+
+	return measurements
+}
+
+func (m *recordingMeter) Int64Gauge(name string, _ ...metric.Int64GaugeOption) (metric.Int64Gauge, error) {
+	return &recordingInt64Gauge{name: name, provider: m.provider}, nil
+}
+
+func (m *recordingMeter) Float64Histogram(
+	name string,
+	_ ...metric.Float64HistogramOption,
+) (metric.Float64Histogram, error) {
+	return &recordingFloat64Histogram{name: name, provider: m.provider}, nil
+}
+
+func (m *recordingMeter) Int64Counter(name string, _ ...metric.Int64CounterOption) (metric.Int64Counter, error) {
+	return &recordingInt64Counter{name: name, provider: m.provider}, nil
+}
+
+func (g *recordingInt64Gauge) Record(_ context.Context, value int64, opts ...metric.RecordOption) {
+	g.provider.record(g.name, float64(value), metric.NewRecordConfig(opts).Attributes())
+}
+
+func (h *recordingFloat64Histogram) Record(_ context.Context, value float64, opts ...metric.RecordOption) {
+	h.provider.record(h.name, value, metric.NewRecordConfig(opts).Attributes())
+}
+
+func (c *recordingInt64Counter) Add(_ context.Context, value int64, opts ...metric.AddOption) {
+	c.provider.record(c.name, float64(value), metric.NewAddConfig(opts).Attributes())
+}
+
+func TestReadyHandler_CheckMetrics(t *testing.T) {
+	t.Parallel()
+
+	t.Run("records status and duration per check", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a ready handler with check metrics
+		provider := &recordingMeterProvider{}
+		handlers := vital.NewHealthHandler(
+			vital.WithCheckers(
+				&mockChecker{name: "database", status: vital.StatusOK},
+				&mockChecker{name: "cache", status: vital.StatusError},
+			),
+			vital.WithReadyOptions(vital.WithCheckMetrics(provider)),
+		)
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/readyz", nil)
+
+		// when: calling the ready endpoint
+		handlers.ServeHTTP(httptest.NewRecorder(), req)
+
+		// then: each check should be recorded
+		statuses := provider.recorded("health.check.status")
+		testastic.Len(t, statuses, 2)
+		testastic.Equal(t, measurement{instrument: "health.check.status", check: "database", value: 1}, statuses[0])
+		testastic.Equal(t, measurement{instrument: "health.check.status", check: "cache", value: 0}, statuses[1])
+
+		durations := provider.recorded("health.check.duration")
+		testastic.Len(t, durations, 2)
+		testastic.Equal(t, "database", durations[0].check)
+		testastic.GreaterOrEqual(t, durations[0].value, 0.0)
+	})
+
+	t.Run("reports readiness changes as span events", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a ready handler with check metrics and a checker that starts failing
+		checker := &countingChecker{name: "database"}
+		handlers := vital.NewHealthHandler(
+			vital.WithCheckers(checker),
+			vital.WithReadyOptions(vital.WithCheckMetrics(&recordingMeterProvider{})),
+		)
+		ctx, _, span := testRecordingSpan(t)
+
+		// when: readiness flips from ok to error
+		handlers.ServeHTTP(httptest.NewRecorder(), httptest.NewRequestWithContext(ctx, http.MethodGet, "/readyz", nil))
+		handlers.ServeHTTP(httptest.NewRecorder(), httptest.NewRequestWithContext(ctx, http.MethodGet, "/readyz", nil))
+		checker.failed.Store(true)
+		handlers.ServeHTTP(httptest.NewRecorder(), httptest.NewRequestWithContext(ctx, http.MethodGet, "/readyz", nil))
+
+		// then: only the change should be recorded
+		events := span.recordedEvents()
+		testastic.Len(t, events, 1)
+		testastic.Equal(t, "health.readiness.changed", events[0].name)
+		testastic.SliceEqual(t, []attribute.KeyValue{
+			attribute.String("health.readiness.from", "ok"),
+			attribute.String("health.readiness.to", "error"),
+		}, events[0].attrs)
+	})
+}
+
+func TestReadinessPoller_Metrics(t *testing.T) {
+	t.Parallel()
+
+	t.Run("records each round of checks", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a poller with metrics
+		provider := &recordingMeterProvider{}
+		poller := vital.NewReadinessPoller(
+			[]vital.Checker{&countingChecker{name: "database"}},
+			vital.WithPollInterval(time.Hour),
+			vital.WithPollMetrics(provider),
+		)
+
+		// when: reading the checks twice from the cache
+		poller.Checks(t.Context())
+		poller.Checks(t.Context())
+
+		// then: only the run should be recorded
+		statuses := provider.recorded("health.check.status")
+		testastic.Len(t, statuses, 1)
+		testastic.Equal(t, "database", statuses[0].check)
+		testastic.Equal(t, 1.0, statuses[0].value)
+	})
+}
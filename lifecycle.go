@@ -0,0 +1,96 @@
+package vital
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// LifecycleState is the coarse state of a service as reported by its probes.
+type LifecycleState int32
+
+const (
+	// LifecycleStarting indicates the service is still warming up.
+	LifecycleStarting LifecycleState = iota
+	// LifecycleReady indicates the service accepts traffic.
+	LifecycleReady
+	// LifecycleNotReady indicates the service has started but should not receive traffic.
+	LifecycleNotReady
+	// LifecycleShuttingDown indicates the service is shutting down.
+	LifecycleShuttingDown
+)
+
+// String returns the lowercase name of the state.
+func (s LifecycleState) String() string {
+	switch s {
+	case LifecycleStarting:
+		return "starting"
+	case LifecycleReady:
+		return "ready"
+	case LifecycleNotReady:
+		return "not_ready"
+	case LifecycleShuttingDown:
+		return "shutting_down"
+	default:
+		return "unknown"
+	}
+}
+
+// Lifecycle tracks whether a service is starting, ready, not ready, or shutting down,
+// and drives the startup and readiness probes through WithLifecycle.
+// Once shutting down, the state no longer changes.
+// It is safe for concurrent use.
+type Lifecycle struct {
+	state atomic.Int32
+}
+
+// NewLifecycle creates a Lifecycle in the starting state.
+func NewLifecycle() *Lifecycle {
+	return &Lifecycle{}
+}
+
+// State returns the current state.
+func (l *Lifecycle) State() LifecycleState {
+	return LifecycleState(l.state.Load())
+}
+
+// SetReady marks the service as ready to accept traffic. This also completes startup.
+func (l *Lifecycle) SetReady() {
+	l.transition(LifecycleReady)
+}
+
+// SetNotReady marks the service as started but not accepting traffic.
+func (l *Lifecycle) SetNotReady() {
+	l.transition(LifecycleNotReady)
+}
+
+// Shutdown marks the service as shutting down, which fails readiness.
+// It matches ShutdownFunc so it can be registered with WithPreShutdownFunc, and always
+// returns nil.
+func (l *Lifecycle) Shutdown(_ context.Context) error {
+	l.state.Store(int32(LifecycleShuttingDown))
+
+	return nil
+}
+
+// Started reports whether the service has left the starting state.
+func (l *Lifecycle) Started() bool {
+	return l.State() != LifecycleStarting
+}
+
+// Ready reports whether the service is ready to accept traffic.
+func (l *Lifecycle) Ready() bool {
+	return l.State() == LifecycleReady
+}
+
+func (l *Lifecycle) transition(next LifecycleState) {
+	for {
+		current := l.state.Load()
+		if LifecycleState(current) == LifecycleShuttingDown {
+			return
+		}
+
+		if l.state.CompareAndSwap(current, int32(next)) {
+			return
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package vital_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/monkescience/testastic"
+	"github.com/monkescience/vital"
+)
+
+func TestLifecycle(t *testing.T) {
+	t.Parallel()
+
+	t.Run("transitions between states", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a new lifecycle
+		lifecycle := vital.NewLifecycle()
+
+		// then: it should start in the starting state
+		testastic.Equal(t, vital.LifecycleStarting, lifecycle.State())
+		testastic.False(t, lifecycle.Started())
+
+		// when: marking it ready and then not ready
+		lifecycle.SetReady()
+		testastic.True(t, lifecycle.Ready())
+
+		lifecycle.SetNotReady()
+
+		// then: it should be started but not ready
+		testastic.Equal(t, vital.LifecycleNotReady, lifecycle.State())
+		testastic.True(t, lifecycle.Started())
+		testastic.False(t, lifecycle.Ready())
+	})
+
+	t.Run("stays shutting down", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a lifecycle that is shutting down
+		lifecycle := vital.NewLifecycle()
+		lifecycle.SetReady()
+
+		err := lifecycle.Shutdown(t.Context())
+		testastic.NoError(t, err)
+
+		// when: trying to mark it ready again
+		lifecycle.SetReady()
+
+		// then: it should remain shutting down
+		testastic.Equal(t, vital.LifecycleShuttingDown, lifecycle.State())
+		testastic.Equal(t, "shutting_down", lifecycle.State().String())
+	})
+
+	t.Run("drives startup and readiness probes", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a health handler driven by a lifecycle with a healthy checker
+		lifecycle := vital.NewLifecycle()
+		checker := &mockChecker{name: "database", status: vital.StatusOK}
+
+		handlers := vital.NewHealthHandler(
+			vital.WithLifecycle(lifecycle),
+			vital.WithCheckers(checker),
+		)
+
+		probe := func(path string) int {
+			responseRecorder := httptest.NewRecorder()
+			req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, path, nil)
+
+			handlers.ServeHTTP(responseRecorder, req)
+
+			return responseRecorder.Code
+		}
+
+		// when: probing while starting
+		// then: startup and readiness should fail
+		testastic.Equal(t, http.StatusServiceUnavailable, probe("/startupz"))
+		testastic.Equal(t, http.StatusServiceUnavailable, probe("/readyz"))
+
+		// when: probing once ready
+		lifecycle.SetReady()
+
+		// then: startup and readiness should succeed
+		testastic.Equal(t, http.StatusOK, probe("/startupz"))
+		testastic.Equal(t, http.StatusOK, probe("/readyz"))
+
+		// when: probing while shutting down
+		_ = lifecycle.Shutdown(t.Context())
+
+		// then: readiness should fail while startup stays complete
+		testastic.Equal(t, http.StatusOK, probe("/startupz"))
+		testastic.Equal(t, http.StatusServiceUnavailable, probe("/readyz"))
+	})
+
+	t.Run("ignores a nil lifecycle", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a health handler with a nil lifecycle
+		handlers := vital.NewHealthHandler(vital.WithLifecycle(nil))
+
+		probe := func(path string) int {
+			responseRecorder := httptest.NewRecorder()
+			req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, path, nil)
+
+			handlers.ServeHTTP(responseRecorder, req)
+
+			return responseRecorder.Code
+		}
+
+		// when: probing startup and readiness
+		// then: both should succeed as without a lifecycle
+		testastic.Equal(t, http.StatusOK, probe("/startupz"))
+		testastic.Equal(t, http.StatusOK, probe("/readyz"))
+	})
+}
@@ -0,0 +1,82 @@
+package vital
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+)
+
+const (
+	listenPIDEnv     = "LISTEN_PID"
+	listenFDsEnv     = "LISTEN_FDS"
+	listenFDNamesEnv = "LISTEN_FDNAMES"
+	// listenFDsStart is the first file descriptor passed by systemd socket activation.
+	listenFDsStart = 3
+)
+
+// ErrInvalidListenFDs is returned when the systemd socket activation environment is malformed.
+var ErrInvalidListenFDs = errors.New("invalid systemd socket activation environment")
+
+// SystemdListeners returns the listeners passed to the process by systemd socket activation
+// (LISTEN_PID and LISTEN_FDS), in file descriptor order. It returns no listeners and no
+// error when the process was not socket activated.
+//
+// Serving on an inherited socket lets the service restart without dropping connections:
+// the socket stays open in systemd while the process is replaced. Pass a listener to the
+// server with WithListener. The activation environment is unset, so child processes do not
+// inherit it.
+func SystemdListeners() ([]net.Listener, error) {
+	pid, fds := os.Getenv(listenPIDEnv), os.Getenv(listenFDsEnv)
+	if pid == "" || fds == "" {
+		return nil, nil
+	}
+
+	defer unsetListenEnv()
+
+	listenPID, err := strconv.Atoi(pid)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidListenFDs, listenPIDEnv, err)
+	}
+
+	if listenPID != os.Getpid() {
+		return nil, nil
+	}
+
+	count, err := strconv.Atoi(fds)
+	if err != nil || count < 0 {
+		return nil, fmt.Errorf("%w: %s=%q", ErrInvalidListenFDs, listenFDsEnv, fds)
+	}
+
+	listeners := make([]net.Listener, 0, count)
+
+	for fd := listenFDsStart; fd < listenFDsStart+count; fd++ {
+		file := os.NewFile(uintptr(fd), "LISTEN_FD_"+strconv.Itoa(fd))
+
+		listener, err := net.FileListener(file)
+		_ = file.Close()
+
+		if err != nil {
+			closeListeners(listeners)
+
+			return nil, fmt.Errorf("listen on inherited file descriptor %d: %w", fd, err)
+		}
+
+		listeners = append(listeners, listener)
+	}
+
+	return listeners, nil
+}
+
+func unsetListenEnv() {
+	_ = os.Unsetenv(listenPIDEnv)
+	_ = os.Unsetenv(listenFDsEnv)
+	_ = os.Unsetenv(listenFDNamesEnv)
+}
+
+func closeListeners(listeners []net.Listener) {
+	for _, listener := range listeners {
+		_ = listener.Close()
+	}
+}
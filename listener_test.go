@@ -0,0 +1,257 @@
+package vital_test
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/monkescience/testastic"
+	"github.com/monkescience/vital"
+)
+
+const (
+	// systemdChildEnv makes TestSystemdListenersChild act as a socket activated process.
+	systemdChildEnv = "VITAL_TEST_SYSTEMD_CHILD"
+	// systemdAddrEnv passes the address of the inherited socket to the child process.
+	systemdAddrEnv = "VITAL_TEST_SYSTEMD_ADDR"
+)
+
+//nolint:paralleltest // Tests modify the process environment
+func TestSystemdListeners(t *testing.T) {
+	t.Run("returns nothing when not socket activated", func(t *testing.T) {
+		// given: no socket activation environment
+		t.Setenv("LISTEN_PID", "")
+		t.Setenv("LISTEN_FDS", "")
+
+		// when: reading the systemd listeners
+		listeners, err := vital.SystemdListeners()
+
+		// then: there should be no listeners and no error
+		testastic.NoError(t, err)
+		testastic.Len(t, listeners, 0)
+	})
+
+	t.Run("ignores activation for another process", func(t *testing.T) {
+		// given: socket activation meant for a different process
+		t.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()+1))
+		t.Setenv("LISTEN_FDS", "1")
+
+		// when: reading the systemd listeners
+		listeners, err := vital.SystemdListeners()
+
+		// then: there should be no listeners and the environment should be unset
+		testastic.NoError(t, err)
+		testastic.Len(t, listeners, 0)
+		testastic.Equal(t, "", os.Getenv("LISTEN_FDS"))
+	})
+
+	t.Run("rejects malformed descriptor count", func(t *testing.T) {
+		// given: a malformed LISTEN_FDS value
+		t.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()))
+		t.Setenv("LISTEN_FDS", "many")
+
+		// when: reading the systemd listeners
+		listeners, err := vital.SystemdListeners()
+
+		// then: it should return an invalid environment error
+		testastic.ErrorIs(t, err, vital.ErrInvalidListenFDs)
+		testastic.Len(t, listeners, 0)
+	})
+	t.Run("serves on an inherited socket", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("file descriptor inheritance is not supported on windows")
+		}
+
+		// given: a child process that inherits a listening socket as file descriptor 3
+		listener, socket := listenForChild(t)
+		child, output := systemdChild(t, "serve", listener.Addr().String(), socket)
+
+		testastic.NoError(t, child.Start())
+
+		_ = listener.Close()
+		_ = socket.Close()
+
+		// when: sending a request to the socket
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "http://"+listener.Addr().String(), nil)
+		testastic.NoError(t, err)
+
+		client := &http.Client{Timeout: 10 * time.Second}
+
+		resp, err := client.Do(req)
+		testastic.NoError(t, err)
+
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+
+		// then: the child should have served it through WithListener and exited cleanly
+		testastic.NoError(t, err)
+		testastic.Equal(t, "inherited", string(body))
+		testastic.NoError(t, waitForChild(t, child, output))
+	})
+
+	t.Run("closes inherited listeners when a descriptor is not a socket", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("file descriptor inheritance is not supported on windows")
+		}
+
+		// given: a child process that inherits a listening socket and a regular file
+		listener, socket := listenForChild(t)
+
+		regular, err := os.CreateTemp(t.TempDir(), "not-a-socket")
+		testastic.NoError(t, err)
+
+		child, output := systemdChild(t, "reject", listener.Addr().String(), socket, regular)
+
+		stdin, err := child.StdinPipe()
+		testastic.NoError(t, err)
+
+		testastic.NoError(t, child.Start())
+
+		// when: the parent releases its copies of the socket
+		_ = listener.Close()
+		_ = socket.Close()
+		_ = regular.Close()
+
+		_, err = io.WriteString(stdin, "closed\n")
+		testastic.NoError(t, err)
+
+		// then: the child should have failed and closed the listener it had already opened
+		testastic.NoError(t, waitForChild(t, child, output))
+	})
+}
+
+// TestSystemdListenersChild runs in the child process started by TestSystemdListeners and is
+// skipped otherwise. Like systemd, it sets LISTEN_PID to its own PID.
+//
+//nolint:paralleltest // Test modifies the process environment
+func TestSystemdListenersChild(t *testing.T) {
+	mode := os.Getenv(systemdChildEnv)
+	if mode == "" {
+		t.Skip("only runs as a child process of TestSystemdListeners")
+	}
+
+	t.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()))
+
+	listeners, err := vital.SystemdListeners()
+
+	switch mode {
+	case "serve":
+		testastic.NoError(t, err)
+		testastic.Len(t, listeners, 1)
+		testastic.Equal(t, "", os.Getenv("LISTEN_FDS"))
+
+		serveOneRequest(t, listeners[0])
+	case "reject":
+		testastic.Error(t, err)
+		testastic.Len(t, listeners, 0)
+
+		// Wait until the parent has closed its copies, so only the child holds the socket.
+		_, err = bufio.NewReader(os.Stdin).ReadString('\n')
+		testastic.NoError(t, err)
+
+		dialer := &net.Dialer{Timeout: 5 * time.Second}
+		_, err = dialer.DialContext(t.Context(), "tcp", os.Getenv(systemdAddrEnv))
+		testastic.Error(t, err)
+	default:
+		t.Fatalf("unknown child mode %q", mode)
+	}
+}
+
+func serveOneRequest(t *testing.T, listener net.Listener) {
+	t.Helper()
+
+	served := make(chan struct{})
+
+	var once sync.Once
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "inherited")
+
+		once.Do(func() { close(served) })
+	})
+
+	server := vital.NewServer(
+		handler,
+		vital.WithListener(listener),
+		vital.WithLogger(slog.New(slog.DiscardHandler)),
+	)
+
+	go func() {
+		_ = server.Start()
+	}()
+
+	select {
+	case <-served:
+	case <-time.After(10 * time.Second):
+		t.Fatal("no request was served")
+	}
+
+	testastic.NoError(t, server.Stop())
+}
+
+// listenForChild returns a TCP listener and a duplicate of its file descriptor to pass to a
+// child process.
+func listenForChild(t *testing.T) (net.Listener, *os.File) {
+	t.Helper()
+
+	listenConfig := net.ListenConfig{}
+
+	listener, err := listenConfig.Listen(t.Context(), "tcp", "127.0.0.1:0")
+	testastic.NoError(t, err)
+
+	tcpListener, ok := listener.(*net.TCPListener)
+	testastic.True(t, ok)
+
+	socket, err := tcpListener.File()
+	testastic.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = listener.Close()
+		_ = socket.Close()
+	})
+
+	return listener, socket
+}
+
+// systemdChild prepares a re-run of the test binary as a socket activated child process that
+// inherits files as file descriptors 3 and up, with addr as the address of the socket.
+func systemdChild(t *testing.T, mode, addr string, files ...*os.File) (*exec.Cmd, *bytes.Buffer) {
+	t.Helper()
+
+	child := exec.CommandContext(t.Context(), os.Args[0], "-test.run=^TestSystemdListenersChild$", "-test.v")
+	child.Env = append(
+		os.Environ(),
+		systemdChildEnv+"="+mode,
+		systemdAddrEnv+"="+addr,
+		"LISTEN_FDS="+strconv.Itoa(len(files)),
+	)
+	child.ExtraFiles = files
+
+	var output bytes.Buffer
+
+	child.Stdout = &output
+	child.Stderr = &output
+
+	return child, &output
+}
+
+func waitForChild(t *testing.T, child *exec.Cmd, output *bytes.Buffer) error {
+	t.Helper()
+
+	err := child.Wait()
+	if err != nil {
+		t.Logf("child output:\n%s", output)
+	}
+
+	return err
+}
@@ -2,12 +2,18 @@
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"slices"
 	"sync"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -19,12 +25,30 @@ type ContextKey struct {
 	Name string
 }
 
-// Registry manages a collection of context keys to extract and log.
+// ContextExtractor derives a log attribute from a context, for values that are not stored
+// under a ContextKey, such as the authenticated user, the tenant, or OTel baggage.
+// Extract reports false when the context carries no value for the attribute.
+type ContextExtractor struct {
+	Name    string
+	Extract func(ctx context.Context) (slog.Value, bool)
+}
+
+// Registry manages a collection of context keys and extractors to log.
 // Each ContextHandler can have its own Registry for isolation.
 type Registry struct {
-	keys   map[ContextKey]struct{}
-	cached []ContextKey
-	mutex  sync.RWMutex
+	keys       map[ContextKey]struct{}
+	cached     []ContextKey
+	extractors []ContextExtractor
+	mutex      sync.RWMutex
+}
+
+//nolint:gochecknoglobals // Process-wide registry shared by libraries and handlers
+var defaultRegistry = NewRegistry()
+
+// DefaultRegistry returns the process-wide Registry. Libraries can register their context
+// keys into it, and handlers created with WithDefaultRegistry log them.
+func DefaultRegistry() *Registry {
+	return defaultRegistry
 }
 
 // NewRegistry creates a new empty Registry.
@@ -44,6 +68,51 @@ func (r *Registry) Register(key ContextKey) {
 	r.cached = nil
 }
 
+// Deregister removes a context key from this registry.
+func (r *Registry) Deregister(key ContextKey) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.keys, key)
+	r.cached = nil
+}
+
+// RegisterExtractor adds a context extractor to this registry.
+// An extractor with the same name as a registered one replaces it.
+func (r *Registry) RegisterExtractor(extractor ContextExtractor) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	idx := slices.IndexFunc(r.extractors, func(registered ContextExtractor) bool {
+		return registered.Name == extractor.Name
+	})
+	if idx >= 0 {
+		r.extractors[idx] = extractor
+
+		return
+	}
+
+	r.extractors = append(r.extractors, extractor)
+}
+
+// DeregisterExtractor removes the context extractor called name from this registry.
+func (r *Registry) DeregisterExtractor(name string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.extractors = slices.DeleteFunc(r.extractors, func(registered ContextExtractor) bool {
+		return registered.Name == name
+	})
+}
+
+// Extractors returns a copy of all registered extractors in registration order.
+func (r *Registry) Extractors() []ContextExtractor {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return slices.Clone(r.extractors)
+}
+
 // Keys returns a copy of all registered keys for safe iteration.
 // The internal cache is invalidated when new keys are registered; callers
 // may freely mutate the returned slice without affecting future calls.
@@ -79,11 +148,19 @@ func (r *Registry) Keys() []ContextKey {
 // When WithBuiltinKeys is used, it also extracts trace_id, span_id, and trace_flags from
 // the OTel span context stored in the context by any OTel-compliant middleware.
 type ContextHandler struct {
-	handler     slog.Handler
-	registry    *Registry
-	builtinKeys bool
+	handler        slog.Handler
+	registry       *Registry
+	builtinKeys    bool
+	spanEvents     bool
+	spanEventLevel slog.Leveler
+	transforms     map[string]ValueTransform
+	contextGroup   string
 }
 
+// ValueTransform rewrites a context attribute value before it is logged,
+// for example to hash or truncate personal data.
+type ValueTransform func(slog.Value) slog.Value
+
 // ContextHandlerOption is a functional option for configuring a ContextHandler.
 type ContextHandlerOption func(*ContextHandler)
 
@@ -95,6 +172,13 @@ func WithRegistry(registry *Registry) ContextHandlerOption {
 	}
 }
 
+// WithDefaultRegistry uses the process-wide DefaultRegistry, so the handler logs keys that
+// other components registered there. Keys added with WithContextKeys after this option are
+// registered into the default registry as well.
+func WithDefaultRegistry() ContextHandlerOption {
+	return WithRegistry(DefaultRegistry())
+}
+
 // WithBuiltinKeys enables automatic extraction of trace_id, span_id, and trace_flags
 // from the OTel span context. This works with any OTel-compliant middleware (e.g., otelhttp).
 func WithBuiltinKeys() ContextHandlerOption {
@@ -103,6 +187,21 @@ func WithBuiltinKeys() ContextHandlerOption {
 	}
 }
 
+// WithSpanEvents records log records at or above level as events on the active OTel span.
+// This keeps error logs visible in traces, including for background goroutines that only
+// carry a span. Records are only recorded when the span in the context is recording.
+// A nil level defaults to slog.LevelError.
+func WithSpanEvents(level slog.Leveler) ContextHandlerOption {
+	return func(h *ContextHandler) {
+		if level == nil {
+			level = slog.LevelError
+		}
+
+		h.spanEvents = true
+		h.spanEventLevel = level
+	}
+}
+
 // WithContextKeys registers specific context keys to be extracted and logged.
 // This is useful for adding custom application-specific keys.
 func WithContextKeys(keys ...ContextKey) ContextHandlerOption {
@@ -113,6 +212,62 @@ func WithContextKeys(keys ...ContextKey) ContextHandlerOption {
 	}
 }
 
+// WithContextExtractors registers extractors that derive log attributes from the context.
+func WithContextExtractors(extractors ...ContextExtractor) ContextHandlerOption {
+	return func(h *ContextHandler) {
+		for _, extractor := range extractors {
+			h.registry.RegisterExtractor(extractor)
+		}
+	}
+}
+
+// WithContextGroup nests the attributes extracted by context keys and extractors under a
+// group called name, so they cannot collide with attributes such as "status" or "error"
+// added at the call site. Trace attributes from WithBuiltinKeys stay at the top level.
+// An empty name keeps the attributes at the top level, which is the default.
+func WithContextGroup(name string) ContextHandlerOption {
+	return func(h *ContextHandler) {
+		h.contextGroup = name
+	}
+}
+
+// WithContextTransform applies transform to the context attribute called name, extracted by a
+// ContextKey or a ContextExtractor, before it is logged. A later transform for the same name
+// replaces an earlier one.
+func WithContextTransform(name string, transform ValueTransform) ContextHandlerOption {
+	return func(h *ContextHandler) {
+		if h.transforms == nil {
+			h.transforms = make(map[string]ValueTransform)
+		}
+
+		h.transforms[name] = transform
+	}
+}
+
+// TruncateValue returns a ValueTransform that shortens string values to at most maxLen runes.
+func TruncateValue(maxLen int) ValueTransform {
+	return func(value slog.Value) slog.Value {
+		runes := []rune(value.Resolve().String())
+		if len(runes) <= maxLen {
+			return value
+		}
+
+		return slog.StringValue(string(runes[:max(maxLen, 0)]))
+	}
+}
+
+// HashValue returns a ValueTransform that replaces values with their hex-encoded HMAC-SHA256
+// under key. Equal values keep producing equal hashes, so records can still be correlated
+// without logging the original identifier.
+func HashValue(key []byte) ValueTransform {
+	return func(value slog.Value) slog.Value {
+		mac := hmac.New(sha256.New, key)
+		_, _ = mac.Write([]byte(value.Resolve().String()))
+
+		return slog.StringValue(hex.EncodeToString(mac.Sum(nil)))
+	}
+}
+
 // NewContextHandler creates a new ContextHandler wrapping the provided handler.
 // If the provided handler is already a ContextHandler, it unwraps it first to avoid nesting.
 // Options can be provided to configure which context keys are extracted.
@@ -143,13 +298,41 @@ func NewContextHandler(handler slog.Handler, opts ...ContextHandlerOption) *Cont
 	return h
 }
 
+type logLevelContextKey struct{}
+
+// ContextWithLogLevel returns a copy of ctx that overrides the minimum log level for records
+// logged with it, for example to capture debug logs for a single request that carries an
+// allowlisted debug header. The override replaces the level of the handler wrapped by
+// ContextHandler, which must pass every record it receives through Handle, as the built-in
+// slog handlers do. The destinations of NewMultiHandlerFromConfig honor it as well.
+func ContextWithLogLevel(ctx context.Context, level slog.Leveler) context.Context {
+	return context.WithValue(ctx, logLevelContextKey{}, level)
+}
+
+// LogLevelFromContext returns the log level override stored in ctx by ContextWithLogLevel.
+func LogLevelFromContext(ctx context.Context) (slog.Leveler, bool) {
+	level, ok := ctx.Value(logLevelContextKey{}).(slog.Leveler)
+
+	return level, ok && level != nil
+}
+
 // Enabled reports whether the handler handles records at the given level.
+// A level override stored in ctx by ContextWithLogLevel takes precedence over the
+// wrapped handler's level.
 func (h *ContextHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	if override, ok := LogLevelFromContext(ctx); ok {
+		return level >= override.Level()
+	}
+
 	return h.handler.Enabled(ctx, level)
 }
 
 // Handle processes the log record, extracting registered context values and adding them as attributes.
 func (h *ContextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if h.spanEvents && record.Level >= h.spanEventLevel.Level() {
+		addSpanLogEvent(ctx, record)
+	}
+
 	if h.builtinKeys {
 		if spanCtx := trace.SpanFromContext(ctx).SpanContext(); spanCtx.IsValid() {
 			record.AddAttrs(
@@ -160,14 +343,7 @@ func (h *ContextHandler) Handle(ctx context.Context, record slog.Record) error {
 		}
 	}
 
-	for _, key := range h.registry.Keys() {
-		if value := ctx.Value(key); value != nil {
-			record.AddAttrs(slog.Attr{
-				Key:   key.Name,
-				Value: slog.AnyValue(value),
-			})
-		}
-	}
+	h.addContextAttrs(ctx, &record)
 
 	err := h.handler.Handle(ctx, record)
 	if err != nil {
@@ -178,27 +354,15 @@ func (h *ContextHandler) Handle(ctx context.Context, record slog.Record) error {
 }
 
 // WithAttrs returns a new handler with the given attributes added.
-// The returned handler preserves the same registry and builtinKeys setting as the original.
+// The returned handler preserves the same registry and settings as the original.
 func (h *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
-	ch := NewContextHandler(
-		h.handler.WithAttrs(attrs),
-		WithRegistry(h.registry),
-	)
-	ch.builtinKeys = h.builtinKeys
-
-	return ch
+	return h.derive(h.handler.WithAttrs(attrs))
 }
 
 // WithGroup returns a new handler with the given group name.
-// The returned handler preserves the same registry and builtinKeys setting as the original.
+// The returned handler preserves the same registry and settings as the original.
 func (h *ContextHandler) WithGroup(name string) slog.Handler {
-	ch := NewContextHandler(
-		h.handler.WithGroup(name),
-		WithRegistry(h.registry),
-	)
-	ch.builtinKeys = h.builtinKeys
-
-	return ch
+	return h.derive(h.handler.WithGroup(name))
 }
 
 // Registry returns the handler's registry for inspection.
@@ -211,39 +375,211 @@ func (h *ContextHandler) Unwrap() slog.Handler {
 	return h.handler
 }
 
+// addContextAttrs adds the attributes extracted by registered keys and extractors to record,
+// nested under the context group if one is configured.
+func (h *ContextHandler) addContextAttrs(ctx context.Context, record *slog.Record) {
+	var attrs []slog.Attr
+
+	for _, key := range h.registry.Keys() {
+		if value := ctx.Value(key); value != nil {
+			attrs = append(attrs, h.contextAttr(key.Name, slog.AnyValue(value)))
+		}
+	}
+
+	for _, extractor := range h.registry.Extractors() {
+		if value, ok := extractor.Extract(ctx); ok {
+			attrs = append(attrs, h.contextAttr(extractor.Name, value))
+		}
+	}
+
+	if len(attrs) == 0 {
+		return
+	}
+
+	if h.contextGroup != "" {
+		record.AddAttrs(slog.Attr{Key: h.contextGroup, Value: slog.GroupValue(attrs...)})
+
+		return
+	}
+
+	record.AddAttrs(attrs...)
+}
+
+// contextAttr builds the attribute for a context value, applying its transform if any.
+func (h *ContextHandler) contextAttr(name string, value slog.Value) slog.Attr {
+	if transform, ok := h.transforms[name]; ok {
+		value = transform(value)
+	}
+
+	return slog.Attr{Key: name, Value: value}
+}
+
+// derive returns a copy of h wrapping handler instead of the original inner handler.
+func (h *ContextHandler) derive(handler slog.Handler) *ContextHandler {
+	ch := *h
+	ch.handler = handler
+
+	return &ch
+}
+
+func addSpanLogEvent(ctx context.Context, record slog.Record) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	attrs := make([]attribute.KeyValue, 0, record.NumAttrs()+1)
+	attrs = append(attrs, attribute.String("log.severity", record.Level.String()))
+
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs = appendSpanAttrs(attrs, "", attr)
+
+		return true
+	})
+
+	span.AddEvent(record.Message, trace.WithTimestamp(record.Time), trace.WithAttributes(attrs...))
+}
+
+func appendSpanAttrs(attrs []attribute.KeyValue, prefix string, attr slog.Attr) []attribute.KeyValue {
+	value := attr.Value.Resolve()
+
+	key := attr.Key
+	if prefix != "" {
+		key = prefix + "." + key
+	}
+
+	switch value.Kind() {
+	case slog.KindGroup:
+		for _, groupAttr := range value.Group() {
+			attrs = appendSpanAttrs(attrs, key, groupAttr)
+		}
+
+		return attrs
+	case slog.KindString:
+		return append(attrs, attribute.String(key, value.String()))
+	case slog.KindInt64:
+		return append(attrs, attribute.Int64(key, value.Int64()))
+	case slog.KindFloat64:
+		return append(attrs, attribute.Float64(key, value.Float64()))
+	case slog.KindBool:
+		return append(attrs, attribute.Bool(key, value.Bool()))
+	case slog.KindAny, slog.KindUint64, slog.KindDuration, slog.KindTime, slog.KindLogValuer:
+		return append(attrs, attribute.String(key, value.String()))
+	}
+
+	return attrs
+}
+
 var (
 	// ErrInvalidLogLevel is returned when an invalid log level is provided.
 	ErrInvalidLogLevel = errors.New("invalid log level")
 	// ErrInvalidLogFormat is returned when an invalid log format is provided.
 	ErrInvalidLogFormat = errors.New("invalid log format")
+	// ErrInvalidLogOutput is returned when an invalid log output is provided.
+	ErrInvalidLogOutput = errors.New("invalid log output")
 )
 
 // LogConfig holds configuration for the logger.
 type LogConfig struct {
 	// Level is the log level (debug, info, warn, error).
 	Level string
-	// Format is the log format (json, text).
+	// Format is the log format (json, text, dev). The dev format writes colored,
+	// human-friendly lines with shortened trace IDs for local development.
 	Format string
 	// AddSource includes the source file and line number in the log.
 	AddSource bool
+	// Output is the named destination (stdout, stderr, discard). Empty means stdout.
+	Output string
+	// Writer is the destination for log records. It takes precedence over Output,
+	// for example to write to a file or a buffer in tests.
+	Writer io.Writer
+}
+
+// Validate checks the configuration without building a handler.
+// All problems are reported at once, joined into a single error.
+func (cfg LogConfig) Validate() error {
+	_, levelErr := parseLogLevel(cfg.Level)
+	formatErr := validateLogFormat(cfg.Format)
+
+	var outputErr error
+	if cfg.Writer == nil {
+		_, outputErr = logOutputWriter(cfg.Output)
+	}
+
+	return joinErrors(levelErr, formatErr, outputErr)
 }
 
 // NewHandlerFromConfig creates a new slog.Handler based on the provided configuration.
-// Returns an error if level or format are invalid.
+// Returns an error if level, format, or output are invalid.
 func NewHandlerFromConfig(cfg LogConfig, opts ...ContextHandlerOption) (slog.Handler, error) {
-	var level slog.Level
+	handler, err := newBaseHandler(cfg)
+	if err != nil {
+		return nil, err
+	}
 
-	switch cfg.Level {
-	case "debug":
-		level = slog.LevelDebug
-	case "info":
-		level = slog.LevelInfo
-	case "warn":
-		level = slog.LevelWarn
-	case "error":
-		level = slog.LevelError
-	default:
-		return nil, fmt.Errorf("%w: %q (must be debug, info, warn, or error)", ErrInvalidLogLevel, cfg.Level)
+	return NewContextHandler(handler, opts...), nil
+}
+
+// NewMultiHandlerFromConfig creates a slog.Handler that fans each record out to one handler
+// per configuration, for example JSON to a file and text to stdout. Context attributes are
+// extracted once and passed to every destination.
+// Returns an error if any configuration is invalid.
+func NewMultiHandlerFromConfig(cfgs []LogConfig, opts ...ContextHandlerOption) (slog.Handler, error) {
+	handlers := make([]slog.Handler, 0, len(cfgs))
+
+	var errs error
+
+	for idx, cfg := range cfgs {
+		handler, err := newBaseHandler(cfg)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("log config %d: %w", idx, err))
+
+			continue
+		}
+
+		handlers = append(handlers, contextLevelHandler{Handler: handler})
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+
+	return NewContextHandler(slog.NewMultiHandler(handlers...), opts...), nil
+}
+
+// contextLevelHandler applies the level override from ContextWithLogLevel to a handler whose
+// level is checked again behind ContextHandler, such as a destination of slog.NewMultiHandler.
+type contextLevelHandler struct {
+	slog.Handler
+}
+
+func (h contextLevelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	if override, ok := LogLevelFromContext(ctx); ok {
+		return level >= override.Level()
+	}
+
+	return h.Handler.Enabled(ctx, level)
+}
+
+func (h contextLevelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextLevelHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextLevelHandler) WithGroup(name string) slog.Handler {
+	return contextLevelHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+func newBaseHandler(cfg LogConfig) (slog.Handler, error) {
+	validateErr := cfg.Validate()
+	if validateErr != nil {
+		return nil, validateErr
+	}
+
+	level, _ := parseLogLevel(cfg.Level)
+
+	writer := cfg.Writer
+	if writer == nil {
+		writer, _ = logOutputWriter(cfg.Output)
 	}
 
 	//nolint:exhaustruct // ReplaceAttr is optional and not needed for basic configuration
@@ -252,16 +588,49 @@ func NewHandlerFromConfig(cfg LogConfig, opts ...ContextHandlerOption) (slog.Han
 		AddSource: cfg.AddSource,
 	}
 
-	var handler slog.Handler
-
 	switch cfg.Format {
 	case "text":
-		handler = slog.NewTextHandler(os.Stdout, handlerOpts)
-	case "json":
-		handler = slog.NewJSONHandler(os.Stdout, handlerOpts)
+		return slog.NewTextHandler(writer, handlerOpts), nil
+	case "dev":
+		return newDevHandler(writer, handlerOpts), nil
 	default:
-		return nil, fmt.Errorf("%w: %q (must be text or json)", ErrInvalidLogFormat, cfg.Format)
+		return slog.NewJSONHandler(writer, handlerOpts), nil
 	}
+}
 
-	return NewContextHandler(handler, opts...), nil
+func logOutputWriter(output string) (io.Writer, error) {
+	switch output {
+	case "", "stdout":
+		return os.Stdout, nil
+	case "stderr":
+		return os.Stderr, nil
+	case "discard":
+		return io.Discard, nil
+	default:
+		return nil, fmt.Errorf("%w: %q (must be stdout, stderr, or discard)", ErrInvalidLogOutput, output)
+	}
+}
+
+func parseLogLevel(level string) (slog.Level, error) {
+	switch level {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return slog.LevelInfo, fmt.Errorf("%w: %q (must be debug, info, warn, or error)", ErrInvalidLogLevel, level)
+	}
+}
+
+func validateLogFormat(format string) error {
+	switch format {
+	case "text", "json", "dev":
+		return nil
+	default:
+		return fmt.Errorf("%w: %q (must be text, json, or dev)", ErrInvalidLogFormat, format)
+	}
 }
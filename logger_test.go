@@ -11,6 +11,7 @@
 
 	"github.com/monkescience/testastic"
 	"github.com/monkescience/vital"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -249,6 +250,56 @@ func TestRegistry(t *testing.T) {
 		testastic.True(t, found)
 	})
 
+	t.Run("deregisters keys and extractors", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a registry with two keys and an extractor
+		registry := vital.NewRegistry()
+
+		key1 := vital.ContextKey{Name: "key1"}
+		key2 := vital.ContextKey{Name: "key2"}
+		registry.Register(key1)
+		registry.Register(key2)
+		registry.RegisterExtractor(vital.ContextExtractor{Name: "tenant", Extract: func(context.Context) (slog.Value, bool) {
+			return slog.Value{}, false
+		}})
+
+		_ = registry.Keys()
+
+		// when: deregistering one key and the extractor
+		registry.Deregister(key1)
+		registry.DeregisterExtractor("tenant")
+
+		// then: only the other key should remain
+		testastic.SliceEqual(t, []vital.ContextKey{key2}, registry.Keys())
+		testastic.Len(t, registry.Extractors(), 0)
+	})
+
+	t.Run("replaces extractors with the same name", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a registry with an extractor
+		registry := vital.NewRegistry()
+		first := vital.ContextExtractor{Name: "tenant", Extract: func(context.Context) (slog.Value, bool) {
+			return slog.StringValue("first"), true
+		}}
+		second := vital.ContextExtractor{Name: "tenant", Extract: func(context.Context) (slog.Value, bool) {
+			return slog.StringValue("second"), true
+		}}
+
+		registry.RegisterExtractor(first)
+
+		// when: registering another extractor with the same name
+		registry.RegisterExtractor(second)
+
+		// then: only the latest extractor should be registered
+		extractors := registry.Extractors()
+		testastic.Len(t, extractors, 1)
+
+		value, _ := extractors[0].Extract(context.Background())
+		testastic.Equal(t, "second", value.String())
+	})
+
 	t.Run("reflects keys registered after first access", func(t *testing.T) {
 		t.Parallel()
 
@@ -480,6 +531,372 @@ func TestContextHandler_WithBuiltinKeys_OTelSpanContext(t *testing.T) {
 	})
 }
 
+func TestContextHandler_WithContextExtractors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("adds derived attributes", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a context handler with an extractor that derives the tenant from a user
+		type user struct{ tenant string }
+
+		type userKey struct{}
+
+		var buf bytes.Buffer
+
+		tenant := vital.ContextExtractor{
+			Name: "tenant",
+			Extract: func(ctx context.Context) (slog.Value, bool) {
+				u, ok := ctx.Value(userKey{}).(user)
+				if !ok {
+					return slog.Value{}, false
+				}
+
+				return slog.StringValue(u.tenant), true
+			},
+		}
+
+		logger := slog.New(vital.NewContextHandler(
+			slog.NewJSONHandler(&buf, nil),
+			vital.WithContextExtractors(tenant),
+		))
+
+		ctx := context.WithValue(context.Background(), userKey{}, user{tenant: "acme"})
+
+		// when: logging with and without the user in the context
+		logger.InfoContext(ctx, "with user")
+		logger.InfoContext(context.Background(), "without user")
+
+		// then: only the first record should carry the derived attribute
+		decoder := json.NewDecoder(&buf)
+
+		var withUser, withoutUser map[string]any
+
+		testastic.NoError(t, decoder.Decode(&withUser))
+		testastic.NoError(t, decoder.Decode(&withoutUser))
+
+		testastic.Equal(t, "acme", withUser["tenant"])
+		testastic.Nil(t, withoutUser["tenant"])
+	})
+}
+
+func TestContextHandler_WithContextTransform(t *testing.T) {
+	t.Parallel()
+
+	t.Run("transforms context values before logging", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a context handler that hashes user IDs and truncates paths
+		var buf bytes.Buffer
+
+		userKey := vital.ContextKey{Name: "user_id"}
+		pathKey := vital.ContextKey{Name: "path"}
+		hashKey := []byte("secret")
+
+		logger := slog.New(vital.NewContextHandler(
+			slog.NewJSONHandler(&buf, nil),
+			vital.WithContextKeys(userKey, pathKey),
+			vital.WithContextTransform("user_id", vital.HashValue(hashKey)),
+			vital.WithContextTransform("path", vital.TruncateValue(4)),
+		))
+
+		ctx := context.WithValue(context.Background(), userKey, "user-123")
+		ctx = context.WithValue(ctx, pathKey, "/users/123")
+
+		// when: logging with the context
+		logger.InfoContext(ctx, "request")
+
+		// then: the values should be transformed
+		var entry map[string]any
+
+		err := json.Unmarshal(buf.Bytes(), &entry)
+		testastic.NoError(t, err)
+
+		expected := vital.HashValue(hashKey)(slog.StringValue("user-123")).String()
+		testastic.Equal[any](t, expected, entry["user_id"])
+		testastic.NotEqual(t, "user-123", expected)
+		testastic.Equal(t, "/use", entry["path"])
+	})
+
+	t.Run("transforms extracted values", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a context handler with a transformed extractor
+		var buf bytes.Buffer
+
+		email := vital.ContextExtractor{Name: "email", Extract: func(context.Context) (slog.Value, bool) {
+			return slog.StringValue("jane@example.com"), true
+		}}
+
+		logger := slog.New(vital.NewContextHandler(
+			slog.NewJSONHandler(&buf, nil),
+			vital.WithContextExtractors(email),
+			vital.WithContextTransform("email", func(slog.Value) slog.Value {
+				return slog.StringValue("***")
+			}),
+		))
+
+		// when: logging
+		logger.InfoContext(context.Background(), "request")
+
+		// then: the extracted value should be masked
+		testastic.Contains(t, buf.String(), `"email":"***"`)
+	})
+}
+
+func TestContextHandler_WithContextGroup(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nests context attributes under the group", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a context handler with a context group, builtin keys, and a custom key
+		var buf bytes.Buffer
+
+		statusKey := vital.ContextKey{Name: "status"}
+
+		logger := slog.New(vital.NewContextHandler(
+			slog.NewJSONHandler(&buf, nil),
+			vital.WithBuiltinKeys(),
+			vital.WithContextKeys(statusKey),
+			vital.WithContextGroup("ctx"),
+		))
+
+		ctx, _ := testSpanContext(t)
+		ctx = context.WithValue(ctx, statusKey, "premium")
+
+		// when: logging an attribute with the same name as the context key
+		logger.InfoContext(ctx, "request", slog.Int("status", 200))
+
+		// then: the context attribute should be nested and the trace attributes top-level
+		var entry map[string]any
+
+		err := json.Unmarshal(buf.Bytes(), &entry)
+		testastic.NoError(t, err)
+
+		group, ok := entry["ctx"].(map[string]any)
+		testastic.True(t, ok)
+		testastic.Equal(t, "premium", group["status"])
+		testastic.Equal(t, 200.0, entry["status"])
+		testastic.NotNil(t, entry["trace_id"])
+	})
+
+	t.Run("omits empty group", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a context handler with a context group and no context values
+		var buf bytes.Buffer
+
+		logger := slog.New(vital.NewContextHandler(
+			slog.NewJSONHandler(&buf, nil),
+			vital.WithContextKeys(vital.ContextKey{Name: "user_id"}),
+			vital.WithContextGroup("ctx"),
+		))
+
+		// when: logging without context values
+		logger.InfoContext(context.Background(), "request")
+
+		// then: no group should be written
+		testastic.NotContains(t, buf.String(), `"ctx"`)
+	})
+}
+
+func TestTruncateValue(t *testing.T) {
+	t.Parallel()
+
+	t.Run("keeps short values", func(t *testing.T) {
+		t.Parallel()
+
+		// when: truncating a value shorter than the limit
+		value := vital.TruncateValue(10)(slog.StringValue("short"))
+
+		// then: it should be unchanged
+		testastic.Equal(t, "short", value.String())
+	})
+
+	t.Run("truncates by runes", func(t *testing.T) {
+		t.Parallel()
+
+		// when: truncating a multi-byte value
+		value := vital.TruncateValue(2)(slog.StringValue("äöü"))
+
+		// then: it should keep whole runes
+		testastic.Equal(t, "äö", value.String())
+	})
+}
+
+func TestContextHandler_WithDefaultRegistry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("logs keys registered in the default registry", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a key registered into the default registry by another component
+		var buf bytes.Buffer
+
+		libraryKey := vital.ContextKey{Name: "library_request_id"}
+		vital.DefaultRegistry().Register(libraryKey)
+
+		t.Cleanup(func() { vital.DefaultRegistry().Deregister(libraryKey) })
+
+		handler := vital.NewContextHandler(slog.NewJSONHandler(&buf, nil), vital.WithDefaultRegistry())
+
+		// when: logging with the key in the context
+		ctx := context.WithValue(context.Background(), libraryKey, "lib-1")
+		slog.New(handler).InfoContext(ctx, "request")
+
+		// then: the handler should use the default registry and log the key
+		testastic.True(t, handler.Registry() == vital.DefaultRegistry())
+		testastic.Contains(t, buf.String(), `"library_request_id":"lib-1"`)
+	})
+}
+
+func TestContextHandler_LogLevelOverride(t *testing.T) {
+	t.Parallel()
+
+	t.Run("logs below handler level with override", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a context handler at info level and a context with a debug override
+		var buf bytes.Buffer
+
+		baseHandler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+		logger := slog.New(vital.NewContextHandler(baseHandler))
+
+		ctx := vital.ContextWithLogLevel(context.Background(), slog.LevelDebug)
+
+		// when: logging at debug level with and without the override
+		logger.DebugContext(context.Background(), "without override")
+		logger.DebugContext(ctx, "with override")
+
+		// then: only the record with the override should be written
+		testastic.NotContains(t, buf.String(), "without override")
+		testastic.Contains(t, buf.String(), "with override")
+	})
+
+	t.Run("override can raise the level", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a context handler at info level and a context with a warn override
+		var buf bytes.Buffer
+
+		baseHandler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+		logger := slog.New(vital.NewContextHandler(baseHandler))
+
+		ctx := vital.ContextWithLogLevel(context.Background(), slog.LevelWarn)
+
+		// when: logging at info level with the override
+		logger.InfoContext(ctx, "suppressed")
+
+		// then: the record should not be written
+		testastic.Equal(t, "", buf.String())
+	})
+
+	t.Run("reads override from context", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a context with and one without an override
+		ctx := vital.ContextWithLogLevel(context.Background(), slog.LevelDebug)
+
+		// when: reading the overrides
+		level, ok := vital.LogLevelFromContext(ctx)
+		_, missing := vital.LogLevelFromContext(context.Background())
+
+		// then: only the context with the override should report it
+		testastic.True(t, ok)
+		testastic.Equal(t, slog.LevelDebug, level.Level())
+		testastic.False(t, missing)
+	})
+}
+
+func TestContextHandler_WithSpanEvents(t *testing.T) {
+	t.Parallel()
+	t.Run("records error logs as span events", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a context handler with span events at error level and a recording span
+		var buf bytes.Buffer
+
+		baseHandler := slog.NewJSONHandler(&buf, nil)
+		handler := vital.NewContextHandler(baseHandler, vital.WithSpanEvents(slog.LevelError))
+		logger := slog.New(handler)
+
+		ctx, _, span := testRecordingSpan(t)
+
+		// when: logging at info and error level
+		logger.InfoContext(ctx, "fetching user")
+		logger.ErrorContext(ctx, "fetch failed",
+			slog.String("user_id", "42"),
+			slog.Group("http", slog.Int("status", 502)),
+		)
+
+		// then: only the error record should be recorded as a span event
+		events := span.recordedEvents()
+		testastic.Equal(t, 1, len(events))
+		testastic.Equal(t, "fetch failed", events[0].name)
+		testastic.DeepEqual(t, []attribute.KeyValue{
+			attribute.String("log.severity", "ERROR"),
+			attribute.String("user_id", "42"),
+			attribute.Int64("http.status", 502),
+		}, events[0].attrs)
+	})
+
+	t.Run("skips span events without recording span", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a context handler with span events and a non-recording span context
+		var buf bytes.Buffer
+
+		baseHandler := slog.NewJSONHandler(&buf, nil)
+		handler := vital.NewContextHandler(baseHandler, vital.WithSpanEvents(slog.LevelError))
+		logger := slog.New(handler)
+
+		ctx, _ := testSpanContext(t)
+
+		// when: logging at error level
+		logger.ErrorContext(ctx, "fetch failed")
+
+		// then: the record should still be logged
+		var logEntry map[string]any
+
+		err := json.Unmarshal(buf.Bytes(), &logEntry)
+		testastic.NoError(t, err)
+
+		testastic.DeepEqual[any](t, "fetch failed", logEntry["msg"])
+	})
+
+	t.Run("preserves span events through WithAttrs", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a context handler with span events, then WithAttrs applied
+		var buf bytes.Buffer
+
+		baseHandler := slog.NewJSONHandler(&buf, nil)
+		handler := vital.NewContextHandler(baseHandler,
+			vital.WithBuiltinKeys(),
+			vital.WithSpanEvents(slog.LevelWarn),
+		)
+		logger := slog.New(handler).With(slog.String("service", "test"))
+
+		ctx, _, span := testRecordingSpan(t)
+
+		// when: logging at warn level
+		logger.WarnContext(ctx, "slow query")
+
+		// then: the record should be recorded as a span event and carry trace context
+		events := span.recordedEvents()
+		testastic.Equal(t, 1, len(events))
+		testastic.Equal(t, "slow query", events[0].name)
+
+		var logEntry map[string]any
+
+		err := json.Unmarshal(buf.Bytes(), &logEntry)
+		testastic.NoError(t, err)
+
+		testastic.DeepEqual[any](t, span.spanCtx.SpanID().String(), logEntry["span_id"])
+	})
+}
+
 func TestNewHandlerFromConfig(t *testing.T) {
 	t.Parallel()
 	t.Run("returns error with empty log level", func(t *testing.T) {
@@ -746,6 +1163,183 @@ func TestNewHandlerFromConfig(t *testing.T) {
 		_, ok := handler.(*vital.ContextHandler)
 		testastic.True(t, ok)
 	})
+
+	t.Run("writes to configured writer", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a config with a writer
+		var buf bytes.Buffer
+
+		cfg := vital.LogConfig{
+			Level:  "info",
+			Format: "text",
+			Writer: &buf,
+		}
+
+		// when: logging through the handler
+		handler, err := vital.NewHandlerFromConfig(cfg)
+		testastic.NoError(t, err)
+
+		slog.New(handler).Info("hello")
+
+		// then: the record should be written to the writer
+		testastic.Contains(t, buf.String(), "msg=hello")
+	})
+
+	t.Run("returns error for invalid output", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a config with an unknown output
+		cfg := vital.LogConfig{
+			Level:  "info",
+			Format: "json",
+			Output: "syslog",
+		}
+
+		// when: creating a handler
+		handler, err := vital.NewHandlerFromConfig(cfg)
+
+		// then: it should return an invalid output error
+		testastic.ErrorIs(t, err, vital.ErrInvalidLogOutput)
+		testastic.Nil(t, handler)
+	})
+}
+
+func TestNewMultiHandlerFromConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fans records out to every destination", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a JSON and a text destination
+		var jsonBuf, textBuf bytes.Buffer
+
+		testKey := vital.ContextKey{Name: "request_id"}
+		cfgs := []vital.LogConfig{
+			{Level: "info", Format: "json", Writer: &jsonBuf},
+			{Level: "info", Format: "text", Writer: &textBuf},
+		}
+
+		handler, err := vital.NewMultiHandlerFromConfig(cfgs, vital.WithContextKeys(testKey))
+		testastic.NoError(t, err)
+
+		// when: logging with a context value
+		ctx := context.WithValue(context.Background(), testKey, "req-1")
+		slog.New(handler).InfoContext(ctx, "hello")
+
+		// then: both destinations should receive the record with the context attribute
+		var entry map[string]any
+
+		err = json.Unmarshal(jsonBuf.Bytes(), &entry)
+		testastic.NoError(t, err)
+		testastic.Equal(t, "hello", entry["msg"])
+		testastic.Equal(t, "req-1", entry["request_id"])
+
+		testastic.Contains(t, textBuf.String(), "request_id=req-1")
+	})
+
+	t.Run("respects each destination's level", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a debug and a warn destination
+		var debugBuf, warnBuf bytes.Buffer
+
+		cfgs := []vital.LogConfig{
+			{Level: "debug", Format: "text", Writer: &debugBuf},
+			{Level: "warn", Format: "text", Writer: &warnBuf},
+		}
+
+		handler, err := vital.NewMultiHandlerFromConfig(cfgs)
+		testastic.NoError(t, err)
+
+		// when: logging at info
+		slog.New(handler).Info("hello")
+
+		// then: only the debug destination should receive it
+		testastic.Contains(t, debugBuf.String(), "msg=hello")
+		testastic.Equal(t, "", warnBuf.String())
+	})
+
+	t.Run("honors the level override from the context", func(t *testing.T) {
+		t.Parallel()
+
+		// given: two info destinations
+		var jsonBuf, textBuf bytes.Buffer
+
+		cfgs := []vital.LogConfig{
+			{Level: "info", Format: "json", Writer: &jsonBuf},
+			{Level: "info", Format: "text", Writer: &textBuf},
+		}
+
+		handler, err := vital.NewMultiHandlerFromConfig(cfgs)
+		testastic.NoError(t, err)
+
+		logger := slog.New(handler).With(slog.String("component", "api"))
+
+		// when: logging at debug with and without a debug override
+		logger.DebugContext(context.Background(), "dropped")
+		logger.DebugContext(vital.ContextWithLogLevel(context.Background(), slog.LevelDebug), "verbose")
+
+		// then: both destinations should receive only the overridden record
+		testastic.Contains(t, jsonBuf.String(), `"msg":"verbose"`)
+		testastic.Contains(t, textBuf.String(), "msg=verbose component=api")
+		testastic.NotContains(t, jsonBuf.String(), "dropped")
+		testastic.NotContains(t, textBuf.String(), "dropped")
+	})
+
+	t.Run("reports invalid configs", func(t *testing.T) {
+		t.Parallel()
+
+		// given: one valid and one invalid config
+		cfgs := []vital.LogConfig{
+			{Level: "info", Format: "json"},
+			{Level: "info", Format: "xml"},
+		}
+
+		// when: creating the handler
+		handler, err := vital.NewMultiHandlerFromConfig(cfgs)
+
+		// then: it should return the invalid format error
+		testastic.ErrorIs(t, err, vital.ErrInvalidLogFormat)
+		testastic.Nil(t, handler)
+	})
+}
+
+func TestLogConfig_Validate(t *testing.T) {
+	t.Parallel()
+	t.Run("accepts valid config", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a config with valid level and format
+		cfg := vital.LogConfig{
+			Level:  "info",
+			Format: "text",
+		}
+
+		// when: validating the config
+		err := cfg.Validate()
+
+		// then: it should succeed
+		testastic.NoError(t, err)
+	})
+
+	t.Run("reports all invalid fields", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a config with both level and format invalid
+		cfg := vital.LogConfig{
+			Level:  "verbose",
+			Format: "xml",
+		}
+
+		// when: validating the config
+		err := cfg.Validate()
+
+		// then: both errors should be reported
+		testastic.ErrorIs(t, err, vital.ErrInvalidLogLevel)
+
+		testastic.ErrorIs(t, err, vital.ErrInvalidLogFormat)
+	})
 }
 
 func BenchmarkRegistryKeys(b *testing.B) {
@@ -0,0 +1,146 @@
+package vital
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+)
+
+const regexpPathPrefix = "re:"
+
+// ErrInvalidPathPattern is returned when a PathMatcher pattern cannot be parsed.
+var ErrInvalidPathPattern = errors.New("invalid path pattern")
+
+// PathMatcher matches request paths against a fixed set of patterns.
+// It gives path-based skip and filter options one shared matching behavior.
+//
+// Supported patterns:
+//   - "/healthz" matches the exact path.
+//   - "/static/*" matches the prefix "/static/" and everything below it.
+//   - "/users/*/avatar" is a glob using path.Match syntax, where * does not cross "/".
+//   - "re:^/v[0-9]+/" is a regular expression, compiled once at construction.
+type PathMatcher struct {
+	exact    map[string]struct{}
+	prefixes []string
+	globs    []string
+	regexps  []*regexp.Regexp
+}
+
+// NewPathMatcher creates a PathMatcher from the given patterns.
+// Returns an error wrapping ErrInvalidPathPattern if a glob or regular expression is malformed.
+func NewPathMatcher(patterns ...string) (*PathMatcher, error) {
+	matcher := &PathMatcher{
+		exact: make(map[string]struct{}),
+	}
+
+	for _, pattern := range patterns {
+		err := matcher.add(pattern)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return matcher, nil
+}
+
+// Match reports whether urlPath matches any of the matcher's patterns.
+// A nil PathMatcher matches nothing.
+func (m *PathMatcher) Match(urlPath string) bool {
+	if m == nil {
+		return false
+	}
+
+	if _, ok := m.exact[urlPath]; ok {
+		return true
+	}
+
+	for _, prefix := range m.prefixes {
+		if strings.HasPrefix(urlPath, prefix) {
+			return true
+		}
+	}
+
+	for _, glob := range m.globs {
+		if matched, _ := path.Match(glob, urlPath); matched {
+			return true
+		}
+	}
+
+	for _, re := range m.regexps {
+		if re.MatchString(urlPath) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MatchRequest reports whether the request path matches any of the matcher's patterns.
+// It can be passed as the predicate to Unless and Only.
+func (m *PathMatcher) MatchRequest(req *http.Request) bool {
+	return m.Match(req.URL.Path)
+}
+
+// Unless applies middleware only to requests for which skip returns false; other requests
+// go straight to the next handler. Use it to exempt routes from third-party middleware,
+// for example health endpoints from authentication:
+//
+//	handler = vital.Unless(probes.MatchRequest, basicAuth)(handler)
+func Unless(skip func(*http.Request) bool, middleware func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		wrapped := middleware(next)
+
+		return http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+			if skip(req) {
+				next.ServeHTTP(writer, req)
+
+				return
+			}
+
+			wrapped.ServeHTTP(writer, req)
+		})
+	}
+}
+
+// Only applies middleware only to requests for which match returns true; other requests
+// go straight to the next handler.
+func Only(match func(*http.Request) bool, middleware func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return Unless(func(req *http.Request) bool { return !match(req) }, middleware)
+}
+
+func (m *PathMatcher) add(pattern string) error {
+	if expr, ok := strings.CutPrefix(pattern, regexpPathPrefix); ok {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return fmt.Errorf("%w: %q: %w", ErrInvalidPathPattern, pattern, err)
+		}
+
+		m.regexps = append(m.regexps, re)
+
+		return nil
+	}
+
+	if prefix, ok := strings.CutSuffix(pattern, "/*"); ok && !strings.ContainsAny(prefix, "*?[\\") {
+		m.prefixes = append(m.prefixes, prefix+"/")
+
+		return nil
+	}
+
+	if strings.ContainsAny(pattern, "*?[\\") {
+		_, err := path.Match(pattern, "")
+		if err != nil {
+			return fmt.Errorf("%w: %q: %w", ErrInvalidPathPattern, pattern, err)
+		}
+
+		m.globs = append(m.globs, pattern)
+
+		return nil
+	}
+
+	m.exact[pattern] = struct{}{}
+
+	return nil
+}
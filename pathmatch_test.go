@@ -0,0 +1,143 @@
+package vital_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/monkescience/testastic"
+	"github.com/monkescience/vital"
+)
+
+func TestPathMatcher(t *testing.T) {
+	t.Parallel()
+	t.Run("matches exact paths", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a matcher with an exact path
+		matcher, err := vital.NewPathMatcher("/livez")
+		testastic.NoError(t, err)
+
+		// when: matching paths
+		// then: only the exact path should match
+		testastic.True(t, matcher.Match("/livez"))
+		testastic.False(t, matcher.Match("/livez/extra"))
+		testastic.False(t, matcher.Match("/readyz"))
+	})
+
+	t.Run("matches path prefixes", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a matcher with a prefix pattern
+		matcher, err := vital.NewPathMatcher("/static/*")
+		testastic.NoError(t, err)
+
+		// when: matching paths
+		// then: all paths below the prefix should match
+		testastic.True(t, matcher.Match("/static/app.js"))
+		testastic.True(t, matcher.Match("/static/img/logo.png"))
+		testastic.False(t, matcher.Match("/static"))
+		testastic.False(t, matcher.Match("/staticfiles/app.js"))
+	})
+
+	t.Run("matches globs within a segment", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a matcher with a glob pattern
+		matcher, err := vital.NewPathMatcher("/users/*/avatar")
+		testastic.NoError(t, err)
+
+		// when: matching paths
+		// then: the wildcard should match a single segment only
+		testastic.True(t, matcher.Match("/users/42/avatar"))
+		testastic.False(t, matcher.Match("/users/42/teams/avatar"))
+	})
+
+	t.Run("matches regular expressions", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a matcher with a regular expression pattern
+		matcher, err := vital.NewPathMatcher(`re:^/v[0-9]+/internal/`)
+		testastic.NoError(t, err)
+
+		// when: matching paths
+		// then: paths matching the expression should match
+		testastic.True(t, matcher.Match("/v2/internal/metrics"))
+		testastic.False(t, matcher.Match("/vx/internal/metrics"))
+	})
+
+	t.Run("rejects malformed patterns", func(t *testing.T) {
+		t.Parallel()
+
+		// given: malformed glob and regular expression patterns
+
+		// when: creating matchers
+		_, globErr := vital.NewPathMatcher("/users/[")
+		_, regexpErr := vital.NewPathMatcher("re:(")
+
+		// then: both should fail
+		testastic.ErrorIs(t, globErr, vital.ErrInvalidPathPattern)
+		testastic.ErrorIs(t, regexpErr, vital.ErrInvalidPathPattern)
+	})
+
+	t.Run("nil matcher matches nothing", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a nil matcher
+		var matcher *vital.PathMatcher
+
+		// when: matching a path
+		// then: it should not match
+		testastic.False(t, matcher.Match("/livez"))
+	})
+}
+
+func TestUnlessAndOnly(t *testing.T) {
+	t.Parallel()
+
+	teapot := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		})
+	}
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	serve := func(handler http.Handler, path string) int {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, path, nil)
+
+		handler.ServeHTTP(recorder, req)
+
+		return recorder.Code
+	}
+
+	matcher, err := vital.NewPathMatcher("/livez", "/static/*")
+	testastic.NoError(t, err)
+
+	t.Run("unless skips matching requests", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a middleware skipped for probe and static paths
+		handler := vital.Unless(matcher.MatchRequest, teapot)(next)
+
+		// when/then: matching paths bypass the middleware and others do not
+		testastic.Equal(t, http.StatusOK, serve(handler, "/livez"))
+		testastic.Equal(t, http.StatusOK, serve(handler, "/static/app.js"))
+		testastic.Equal(t, http.StatusTeapot, serve(handler, "/api/users"))
+	})
+
+	t.Run("only applies to matching requests", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a middleware applied only to probe and static paths
+		handler := vital.Only(matcher.MatchRequest, teapot)(next)
+
+		// when/then: only matching paths go through the middleware
+		testastic.Equal(t, http.StatusTeapot, serve(handler, "/livez"))
+		testastic.Equal(t, http.StatusOK, serve(handler, "/api/users"))
+	})
+}
@@ -0,0 +1,223 @@
+package vital
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	defaultPollInterval       = 10 * time.Second
+	defaultStalenessIntervals = 3
+)
+
+// Compile-time check that ReadinessPoller implements Restartable.
+var _ Restartable = (*ReadinessPoller)(nil)
+
+// ReadinessPoller runs readiness checkers on a background interval and caches the results,
+// so frequent probes do not hammer dependencies such as databases.
+// Use it with WithReadinessPoller and register Shutdown with WithShutdownFunc.
+type ReadinessPoller struct {
+	checkers     []Checker
+	interval     time.Duration
+	maxStaleness time.Duration
+	timeout      time.Duration
+	metrics      *checkMetrics
+	loop         *BackgroundLoop
+
+	// running tracks check goroutines started by the background loop, which may outlive a
+	// canceled poll.
+	running sync.WaitGroup
+
+	refreshMutex sync.Mutex
+	mutex        sync.RWMutex
+	checks       []CheckResponse
+	updatedAt    time.Time
+}
+
+// ReadinessPollerOption configures a ReadinessPoller.
+type ReadinessPollerOption func(*ReadinessPoller)
+
+// WithPollInterval sets how often the checkers run in the background. The default is 10 seconds.
+func WithPollInterval(d time.Duration) ReadinessPollerOption {
+	return func(p *ReadinessPoller) { p.interval = d }
+}
+
+// WithMaxStaleness sets how old cached results may get before a request runs the checks
+// synchronously. The default is three poll intervals.
+func WithMaxStaleness(d time.Duration) ReadinessPollerOption {
+	return func(p *ReadinessPoller) { p.maxStaleness = d }
+}
+
+// WithPollTimeout sets the maximum time allowed for one round of checks. The default is
+// 2 seconds. A value less than or equal to zero disables the timeout.
+func WithPollTimeout(d time.Duration) ReadinessPollerOption {
+	return func(p *ReadinessPoller) { p.timeout = d }
+}
+
+// WithPollMetrics records the health.check.status gauge and health.check.duration histogram
+// for every round of checks. A nil provider uses the global meter provider.
+func WithPollMetrics(provider metric.MeterProvider) ReadinessPollerOption {
+	return func(p *ReadinessPoller) { p.metrics = newCheckMetrics(provider) }
+}
+
+// NewReadinessPoller creates a ReadinessPoller for checkers. The poller does not run
+// until Start is called; until then, requests run the checks synchronously.
+func NewReadinessPoller(checkers []Checker, opts ...ReadinessPollerOption) *ReadinessPoller {
+	//nolint:exhaustruct // Cached results are populated by the first poll
+	poller := &ReadinessPoller{
+		checkers: checkers,
+		interval: defaultPollInterval,
+		timeout:  defaultReadyTimeout,
+	}
+
+	for _, o := range opts {
+		o(poller)
+	}
+
+	if poller.interval <= 0 {
+		poller.interval = defaultPollInterval
+	}
+
+	if poller.maxStaleness <= 0 {
+		poller.maxStaleness = defaultStalenessIntervals * poller.interval
+	}
+
+	poller.loop = NewBackgroundLoop(poller.poll)
+
+	return poller
+}
+
+// Start runs the checks immediately and then on every poll interval until ctx is canceled
+// or Shutdown is called.
+func (p *ReadinessPoller) Start(ctx context.Context) {
+	p.loop.Start(ctx)
+}
+
+// Restart restarts the background polling loop.
+func (p *ReadinessPoller) Restart(ctx context.Context) error {
+	return p.loop.Restart(ctx)
+}
+
+// Shutdown stops the background polling loop and waits for its checks to return, or for ctx
+// to expire. Checks that ignore cancellation keep Shutdown waiting until then.
+// It matches ShutdownFunc so it can be registered with WithShutdownFunc.
+func (p *ReadinessPoller) Shutdown(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err := p.loop.Shutdown(ctx)
+	if err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		p.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("wait for readiness checks: %w", ctx.Err())
+	}
+}
+
+// Invalidate discards the cached results, so the next request runs the checks synchronously.
+func (p *ReadinessPoller) Invalidate() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.checks = nil
+	p.updatedAt = time.Time{}
+}
+
+// Checks returns the cached check results, running the checks first if the cache is
+// empty or older than the maximum staleness.
+func (p *ReadinessPoller) Checks(ctx context.Context) []CheckResponse {
+	if checks, ok := p.cached(); ok {
+		return checks
+	}
+
+	// Detach from request cancellation so a disconnecting client does not cache failures.
+	return p.refresh(withoutCancelOrBackground(ctx), false)
+}
+
+// Status returns the overall status of the results returned by Checks, together with the results.
+func (p *ReadinessPoller) Status(ctx context.Context) (Status, []CheckResponse) {
+	checks := p.Checks(ctx)
+
+	return overallStatus(p.checkers, checks), checks
+}
+
+func (p *ReadinessPoller) poll(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	ctx = withCheckTracker(ctx, &p.running)
+
+	for {
+		p.refresh(ctx, true)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *ReadinessPoller) cached() ([]CheckResponse, bool) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	if p.checks == nil || time.Since(p.updatedAt) > p.maxStaleness {
+		return nil, false
+	}
+
+	return slices.Clone(p.checks), true
+}
+
+// refresh runs the checks and stores the results. Concurrent callers share one run:
+// unless force is set, a caller that waited for another refresh reuses its results.
+func (p *ReadinessPoller) refresh(ctx context.Context, force bool) []CheckResponse {
+	p.refreshMutex.Lock()
+	defer p.refreshMutex.Unlock()
+
+	if !force {
+		if checks, ok := p.cached(); ok {
+			return checks
+		}
+	}
+
+	checkCtx, cancel := contextWithTimeoutIfNeeded(ctx, p.timeout)
+	if cancel != nil {
+		defer cancel()
+	}
+
+	checks := runAllChecks(checkCtx, p.checkers)
+
+	// Results interrupted by shutdown say nothing about the dependencies, so keep the old ones.
+	if ctx.Err() != nil {
+		return checks
+	}
+
+	p.mutex.Lock()
+	p.checks = checks
+	p.updatedAt = time.Now()
+	p.mutex.Unlock()
+
+	if p.metrics != nil {
+		p.metrics.recordChecks(ctx, checks)
+	}
+
+	return slices.Clone(checks)
+}
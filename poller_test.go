@@ -0,0 +1,244 @@
+package vital_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/monkescience/testastic"
+	"github.com/monkescience/vital"
+)
+
+// countingChecker counts how often it runs and reports a configurable status.
+type countingChecker struct {
+	name   string
+	calls  atomic.Int32
+	failed atomic.Bool
+}
+
+func (c *countingChecker) Name() string {
+	return c.name
+}
+
+func (c *countingChecker) Check(_ context.Context) (vital.Status, string) {
+	c.calls.Add(1)
+
+	if c.failed.Load() {
+		return vital.StatusError, "unavailable"
+	}
+
+	return vital.StatusOK, "connected"
+}
+
+// stopAwareChecker counts calls made after the test marked the poller as stopped.
+type stopAwareChecker struct {
+	name           string
+	calls          atomic.Int32
+	stopped        atomic.Bool
+	callsAfterStop atomic.Int32
+}
+
+func (c *stopAwareChecker) Name() string {
+	return c.name
+}
+
+func (c *stopAwareChecker) Check(_ context.Context) (vital.Status, string) {
+	if c.stopped.Load() {
+		c.callsAfterStop.Add(1)
+	}
+
+	c.calls.Add(1)
+
+	return vital.StatusOK, "connected"
+}
+
+func TestReadinessPoller(t *testing.T) {
+	t.Parallel()
+
+	t.Run("serves cached results to the ready endpoint", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a ready handler backed by a poller that has not been started
+		checker := &countingChecker{name: "database"}
+		poller := vital.NewReadinessPoller([]vital.Checker{checker}, vital.WithPollInterval(time.Hour))
+
+		handlers := vital.NewHealthHandler(
+			vital.WithReadyOptions(vital.WithReadinessPoller(poller)),
+		)
+
+		// when: calling the ready endpoint several times
+		for range 5 {
+			responseRecorder := httptest.NewRecorder()
+			req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/readyz", nil)
+
+			handlers.ServeHTTP(responseRecorder, req)
+
+			testastic.Equal(t, http.StatusOK, responseRecorder.Code)
+
+			var response vital.ReadyResponse
+
+			err := json.NewDecoder(responseRecorder.Body).Decode(&response)
+			testastic.NoError(t, err)
+
+			testastic.Equal(t, "database", response.Checks[0].Name)
+		}
+
+		// then: the checker should only have run once
+		testastic.Equal(t, int32(1), checker.calls.Load())
+	})
+
+	t.Run("invalidate forces a fresh run", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a poller with cached healthy results
+		checker := &countingChecker{name: "database"}
+		poller := vital.NewReadinessPoller([]vital.Checker{checker}, vital.WithPollInterval(time.Hour))
+
+		checks := poller.Checks(t.Context())
+		testastic.Equal(t, vital.StatusOK, checks[0].Status)
+
+		// when: the dependency fails and the cache is invalidated
+		checker.failed.Store(true)
+		poller.Invalidate()
+
+		checks = poller.Checks(t.Context())
+
+		// then: the new results should be reported
+		testastic.Equal(t, vital.StatusError, checks[0].Status)
+		testastic.Equal(t, int32(2), checker.calls.Load())
+	})
+
+	t.Run("reruns checks when results are stale", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a poller with a very short maximum staleness
+		checker := &countingChecker{name: "database"}
+		poller := vital.NewReadinessPoller(
+			[]vital.Checker{checker},
+			vital.WithPollInterval(time.Hour),
+			vital.WithMaxStaleness(time.Millisecond),
+		)
+
+		poller.Checks(t.Context())
+
+		// when: requesting results after they went stale
+		time.Sleep(5 * time.Millisecond)
+		poller.Checks(t.Context())
+
+		// then: the checks should have run again
+		testastic.Equal(t, int32(2), checker.calls.Load())
+	})
+
+	t.Run("polls in the background until shut down", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a started poller with a short interval
+		checker := &stopAwareChecker{name: "database"}
+		poller := vital.NewReadinessPoller([]vital.Checker{checker}, vital.WithPollInterval(5*time.Millisecond))
+
+		poller.Start(t.Context())
+
+		// when: waiting for several polls and shutting down
+		deadline := time.Now().Add(5 * time.Second)
+		for checker.calls.Load() < 3 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+
+		err := poller.Shutdown(t.Context())
+		testastic.NoError(t, err)
+
+		checker.stopped.Store(true)
+		calls := checker.calls.Load()
+
+		time.Sleep(20 * time.Millisecond)
+
+		// then: the checker should have run repeatedly and never after shutdown returned
+		testastic.GreaterOrEqual(t, calls, int32(3))
+		testastic.Equal(t, int32(0), checker.callsAfterStop.Load())
+	})
+
+	t.Run("shutdown waits for running checks", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a started poller whose check is blocked
+		started := make(chan struct{})
+		release := make(chan struct{})
+
+		var finished atomic.Bool
+
+		checker := vital.NewChecker("database", func(_ context.Context) (vital.Status, string) {
+			close(started)
+			<-release
+			finished.Store(true)
+
+			return vital.StatusOK, ""
+		})
+
+		poller := vital.NewReadinessPoller([]vital.Checker{checker}, vital.WithPollInterval(time.Hour))
+		poller.Start(t.Context())
+		<-started
+
+		// when: shutting down while the check ignores cancellation
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			close(release)
+		}()
+
+		err := poller.Shutdown(t.Context())
+
+		// then: shutdown should return only after the check finished
+		testastic.NoError(t, err)
+		testastic.True(t, finished.Load())
+	})
+
+	t.Run("shutdown gives up on checks when the context expires", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a started poller whose check never returns on its own
+		started := make(chan struct{})
+		release := make(chan struct{})
+		t.Cleanup(func() { close(release) })
+
+		checker := vital.NewChecker("database", func(_ context.Context) (vital.Status, string) {
+			close(started)
+			<-release
+
+			return vital.StatusOK, ""
+		})
+
+		poller := vital.NewReadinessPoller([]vital.Checker{checker}, vital.WithPollInterval(time.Hour))
+		poller.Start(t.Context())
+		<-started
+
+		// when: shutting down with a short deadline
+		ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+		defer cancel()
+
+		err := poller.Shutdown(ctx)
+
+		// then: it should report the expired context
+		testastic.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("reports overall status", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a poller with a failing checker
+		checker := &countingChecker{name: "database"}
+		checker.failed.Store(true)
+
+		poller := vital.NewReadinessPoller([]vital.Checker{checker}, vital.WithPollInterval(time.Hour))
+
+		// when: reading the status
+		status, checks := poller.Status(t.Context())
+
+		// then: it should report the failure with the results
+		testastic.Equal(t, vital.StatusError, status)
+		testastic.Len(t, checks, 1)
+		testastic.Equal(t, "unavailable", checks[0].Message)
+	})
+}
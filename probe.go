@@ -0,0 +1,62 @@
+package vital
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+const (
+	defaultProbeTimeout = 5 * time.Second
+	probeExitHealthy    = 0
+	probeExitUnhealthy  = 1
+)
+
+// ErrProbeStatus is returned by Probe when the endpoint responds with a non-2xx status.
+var ErrProbeStatus = errors.New("unexpected probe status")
+
+// Probe sends a GET request to url with http.DefaultClient and succeeds when the response
+// status is 2xx.
+func Probe(ctx context.Context, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("create probe request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send probe request: %w", err)
+	}
+
+	_ = resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w: %d", ErrProbeStatus, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// ProbeCommand probes url and returns a process exit code: 0 when the endpoint is healthy
+// and 1 otherwise, with the failure written to stderr. The probe is bounded by a five second
+// timeout. It lets distroless images define a container health check without shipping curl:
+//
+//	if len(os.Args) > 1 && os.Args[1] == "-healthcheck" {
+//		os.Exit(vital.ProbeCommand("http://127.0.0.1:8080/livez"))
+//	}
+func ProbeCommand(url string) int {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultProbeTimeout)
+	defer cancel()
+
+	err := Probe(ctx, url)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "health probe failed: %v\n", err)
+
+		return probeExitUnhealthy
+	}
+
+	return probeExitHealthy
+}
@@ -0,0 +1,65 @@
+package vital_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/monkescience/testastic"
+	"github.com/monkescience/vital"
+)
+
+func TestProbe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("succeeds on healthy endpoint", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a server with health endpoints
+		server := httptest.NewServer(vital.NewHealthHandler())
+		t.Cleanup(server.Close)
+
+		// when: probing the liveness endpoint
+		err := vital.Probe(t.Context(), server.URL+"/livez")
+
+		// then: it should succeed
+		testastic.NoError(t, err)
+	})
+
+	t.Run("fails on unhealthy status", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a server responding with service unavailable
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		t.Cleanup(server.Close)
+
+		// when: probing the server
+		err := vital.Probe(t.Context(), server.URL)
+
+		// then: it should report the status
+		testastic.ErrorIs(t, err, vital.ErrProbeStatus)
+		testastic.Contains(t, err.Error(), "503")
+	})
+}
+
+func TestProbeCommand(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns exit code for probe result", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a server with health endpoints
+		server := httptest.NewServer(vital.NewHealthHandler())
+		t.Cleanup(server.Close)
+
+		// when: probing a healthy and a missing endpoint
+		healthy := vital.ProbeCommand(server.URL + "/livez")
+		missing := vital.ProbeCommand(server.URL + "/missing")
+
+		// then: the exit codes should reflect the results
+		testastic.Equal(t, 0, healthy)
+		testastic.Equal(t, 1, missing)
+	})
+}
@@ -0,0 +1,58 @@
+package vital
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	panicsMetric  = "vital.panics"
+	componentAttr = "vital.component"
+
+	componentBackgroundLoop = "background_loop"
+	componentHealthChecker  = "health_checker"
+)
+
+// countPanic adds a recovered panic to the vital.panics counter of the global meter provider.
+// The instrument is looked up per panic, so a provider registered later is used as well.
+func countPanic(ctx context.Context, component string) {
+	counter, err := otel.GetMeterProvider().Meter(meterName).Int64Counter(
+		panicsMetric,
+		metric.WithDescription("Number of panics recovered by vital."),
+		metric.WithUnit("{panic}"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	counter.Add(ctx, 1, metric.WithAttributes(attribute.String(componentAttr, component)))
+}
+
+// reportPanic logs a recovered panic with its stack trace, records it as an event on the
+// active span, and counts it in the vital.panics metric for component, so panics look the
+// same wherever vital recovers them.
+func reportPanic(
+	ctx context.Context,
+	logger *slog.Logger,
+	component, message string,
+	recovered any,
+	attrs ...slog.Attr,
+) {
+	panicValue := fmt.Sprint(recovered)
+
+	attrs = append(attrs,
+		slog.String("panic", panicValue),
+		slog.String("stack", string(debug.Stack())),
+	)
+	logger.LogAttrs(ctx, slog.LevelError, message, attrs...)
+
+	AddSpanEvent(ctx, message, attribute.String("panic", panicValue))
+
+	countPanic(ctx, component)
+}
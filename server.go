@@ -3,15 +3,20 @@
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"os/signal"
 	"slices"
 	"sync"
 	"syscall"
 	"time"
+
+	"golang.org/x/net/netutil"
 )
 
 const (
@@ -28,6 +33,9 @@
 	ErrServerAddrRequired = errors.New("server address is required")
 	// ErrIncompleteTLSConfig is returned when TLS is enabled without both certificate files.
 	ErrIncompleteTLSConfig = errors.New("tls requires both certificate and key paths")
+	// ErrIncompleteClientCertAuth is returned when client certificate auth is enabled
+	// without TLS or without a client CA pool.
+	ErrIncompleteClientCertAuth = errors.New("client certificate auth requires tls and a client ca pool")
 	// ErrShutdownHookPanic is returned when a shutdown hook panics.
 	ErrShutdownHookPanic = errors.New("shutdown hook panicked")
 )
@@ -35,19 +43,39 @@
 // ShutdownFunc is a cleanup hook that runs during server shutdown.
 type ShutdownFunc func(context.Context) error
 
+// Flusher is implemented by components that buffer data, such as telemetry exporters,
+// log handlers, or audit sinks, and must write it out before the process exits.
+type Flusher interface {
+	Flush(ctx context.Context) error
+}
+
 // Server wraps http.Server with opinionated lifecycle helpers for services.
 type Server struct {
 	*http.Server
 
 	useTLS               bool
+	clientCertAuth       bool
 	keyPath              string
 	certificatePath      string
 	shutdownTimeout      time.Duration
+	preShutdownFuncs     []ShutdownFunc
 	shutdownFuncs        []ShutdownFunc
 	shutdownHooksTimeout time.Duration
 	shutdownOnce         sync.Once
 	shutdownErr          error
 	logger               *slog.Logger
+	healthOpts           []HealthHandlerOption
+	mountHealth          bool
+	listener             net.Listener
+	maxConnections       int
+	closeOnDrain         bool
+	backgroundLoops      []*BackgroundLoop
+	flushers             []Flusher
+
+	// loopsMutex orders starting the background loops against shutting them down, so Start
+	// does not start loops after the shutdown hooks have run.
+	loopsMutex sync.Mutex
+	stopping   bool
 }
 
 // ServerOption is a functional option for configuring a Server.
@@ -69,6 +97,22 @@ func WithTLS(certPath, keyPath string) ServerOption {
 	}
 }
 
+// WithClientCertAuth enables mutual TLS: clients must present a certificate that verifies
+// against clientCAs. It requires WithTLS. Use VerifiedClientCertificate in handlers to
+// authorize requests based on the client certificate.
+func WithClientCertAuth(clientCAs *x509.CertPool) ServerOption {
+	return func(s *Server) {
+		if s.TLSConfig == nil {
+			//nolint:exhaustruct // Only client authentication is configured here
+			s.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+
+		s.clientCertAuth = true
+		s.TLSConfig.ClientCAs = clientCAs
+		s.TLSConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+}
+
 // WithShutdownTimeout sets the graceful shutdown timeout.
 func WithShutdownTimeout(timeout time.Duration) ServerOption {
 	return func(s *Server) {
@@ -76,6 +120,19 @@ func WithShutdownTimeout(timeout time.Duration) ServerOption {
 	}
 }
 
+// WithListener serves on an existing listener instead of listening on the server address,
+// for example one inherited through systemd socket activation with SystemdListeners.
+// The server address is not required when a listener is set. A nil listener is silently ignored.
+func WithListener(listener net.Listener) ServerOption {
+	return func(s *Server) {
+		if listener == nil {
+			return
+		}
+
+		s.listener = listener
+	}
+}
+
 // WithShutdownFunc registers a cleanup hook that runs during shutdown.
 // A nil fn is silently ignored.
 func WithShutdownFunc(fn ShutdownFunc) ServerOption {
@@ -88,6 +145,98 @@ func WithShutdownFunc(fn ShutdownFunc) ServerOption {
 	}
 }
 
+// WithBackgroundLoops ties loops to the server lifecycle: they are started by Start, Run,
+// and RunContext, and shut down with the other shutdown hooks after the server has stopped
+// accepting requests, so workers outlive in-flight requests. Shutdown hooks run in reverse
+// registration order. Use WithLoopShutdownTimeout on a loop to bound its shutdown
+// individually. Nil loops are silently ignored.
+func WithBackgroundLoops(loops ...*BackgroundLoop) ServerOption {
+	return func(s *Server) {
+		for _, loop := range loops {
+			if loop == nil {
+				continue
+			}
+
+			s.backgroundLoops = append(s.backgroundLoops, loop)
+			s.shutdownFuncs = append(s.shutdownFuncs, loop.Shutdown)
+		}
+	}
+}
+
+// WithFlushers registers components that are flushed at the end of shutdown, after all
+// shutdown hooks have run, so data produced while stopping workers is not lost.
+// Flushers run in registration order and share the shutdown hooks timeout.
+// Nil flushers are silently ignored.
+func WithFlushers(flushers ...Flusher) ServerOption {
+	return func(s *Server) {
+		for _, flusher := range flushers {
+			if flusher != nil {
+				s.flushers = append(s.flushers, flusher)
+			}
+		}
+	}
+}
+
+// WithPreShutdownFunc registers a hook that runs when shutdown begins, before the server
+// stops accepting connections. Use it to fail readiness, for example with Lifecycle.Shutdown.
+// Pre-shutdown hooks run in registration order. A nil fn is silently ignored.
+func WithPreShutdownFunc(fn ShutdownFunc) ServerOption {
+	return func(s *Server) {
+		if fn == nil {
+			return
+		}
+
+		s.preShutdownFuncs = append(s.preShutdownFuncs, fn)
+	}
+}
+
+// WithDrainPeriod fails readiness through lifecycle when shutdown begins and then waits
+// for period before the server stops accepting connections, so load balancers can observe
+// the failing readiness probe and stop routing new requests first.
+// The drain runs as a pre-shutdown hook and counts against the shutdown timeout, which
+// should therefore be longer than period. A nil lifecycle is silently ignored.
+func WithDrainPeriod(lifecycle *Lifecycle, period time.Duration) ServerOption {
+	return func(s *Server) {
+		if lifecycle == nil {
+			return
+		}
+
+		s.preShutdownFuncs = append(s.preShutdownFuncs, func(ctx context.Context) error {
+			_ = lifecycle.Shutdown(ctx)
+
+			s.logger.InfoContext(ctx, "draining server", slog.String("period", period.String()))
+
+			return waitForDrain(ctx, period)
+		})
+	}
+}
+
+// WithDisableKeepAlivesDuringDrain disables HTTP keep-alives as soon as shutdown begins,
+// before pre-shutdown hooks and the drain period run. Idle connections are closed and
+// responses on active connections carry "Connection: close", so load balancers open new
+// connections to other instances instead of reusing this one.
+func WithDisableKeepAlivesDuringDrain() ServerOption {
+	return func(s *Server) {
+		s.closeOnDrain = true
+	}
+}
+
+func waitForDrain(ctx context.Context, period time.Duration) error {
+	if period <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(period)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("drain interrupted: %w", ctx.Err())
+	}
+}
+
 // WithShutdownHooksTimeout sets the maximum duration allotted to shutdown hooks.
 // If unset, hooks use the same timeout as graceful server shutdown.
 func WithShutdownHooksTimeout(timeout time.Duration) ServerOption {
@@ -124,6 +273,31 @@ func WithIdleTimeout(timeout time.Duration) ServerOption {
 	}
 }
 
+// WithMaxHeaderBytes sets the maximum size of request headers, including the request line.
+// The default is http.DefaultMaxHeaderBytes (1 MB).
+func WithMaxHeaderBytes(n int) ServerOption {
+	return func(s *Server) {
+		s.MaxHeaderBytes = n
+	}
+}
+
+// WithMaxConnections limits the number of simultaneously accepted connections to n.
+// Further connections wait in the listen backlog until an accepted one is closed.
+// A value less than or equal to zero disables the limit, which is the default.
+func WithMaxConnections(n int) ServerOption {
+	return func(s *Server) {
+		s.maxConnections = n
+	}
+}
+
+// WithConnState sets a hook that is called when a client connection changes state,
+// for example to count open or idle connections.
+func WithConnState(fn func(net.Conn, http.ConnState)) ServerOption {
+	return func(s *Server) {
+		s.ConnState = fn
+	}
+}
+
 // WithLogger sets the structured logger for the server.
 // A nil logger is silently ignored; the default slog.Default() is kept.
 func WithLogger(logger *slog.Logger) ServerOption {
@@ -137,6 +311,18 @@ func WithLogger(logger *slog.Logger) ServerOption {
 	}
 }
 
+// WithHealth mounts the health endpoints /livez, /startupz, and /readyz (plus /version with
+// WithBuildInfo) in front of the server's handler, configured with opts. GET and HEAD
+// requests for exactly these paths are served by the health handler; all other requests
+// reach the handler unchanged.
+// Calling WithHealth more than once appends to the health handler options.
+func WithHealth(opts ...HealthHandlerOption) ServerOption {
+	return func(s *Server) {
+		s.mountHealth = true
+		s.healthOpts = append(s.healthOpts, opts...)
+	}
+}
+
 // NewServer creates a new Server with the provided handler and options.
 //
 // Defaults: ReadTimeout 30s, ReadHeaderTimeout 10s, WriteTimeout 10s,
@@ -167,20 +353,134 @@ func NewServer(handler http.Handler, opts ...ServerOption) *Server {
 		opt(server)
 	}
 
+	if server.mountHealth {
+		server.Handler = withHealthRoutes(server.Handler, newHandlerConfig(server.healthOpts...))
+	}
+
 	return server
 }
 
+// withHealthRoutes serves GET and HEAD requests for the exact health paths and passes every
+// other request to handler unchanged, so the application keeps its own routing.
+func withHealthRoutes(handler http.Handler, healthCfg handlerConfig) http.Handler {
+	if handler == nil {
+		handler = http.DefaultServeMux
+	}
+
+	healthHandler := healthCfg.handler()
+	healthPaths := healthCfg.paths()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if (r.Method == http.MethodGet || r.Method == http.MethodHead) && slices.Contains(healthPaths, r.URL.Path) {
+			healthHandler.ServeHTTP(w, r)
+
+			return
+		}
+
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// ServerConfig holds declarative server configuration, for example loaded from a file or
+// environment. Zero values keep the defaults used by NewServer.
+type ServerConfig struct {
+	// Port is the TCP port to listen on.
+	Port int
+	// TLSCertPath is the path to the TLS certificate. TLS is enabled when either path is set.
+	TLSCertPath string
+	// TLSKeyPath is the path to the TLS private key.
+	TLSKeyPath string
+	// ShutdownTimeout is the graceful shutdown timeout.
+	ShutdownTimeout time.Duration
+	// ShutdownHooksTimeout is the timeout budget for shutdown hooks.
+	ShutdownHooksTimeout time.Duration
+	// ReadTimeout is the maximum duration for reading the entire request.
+	ReadTimeout time.Duration
+	// ReadHeaderTimeout is the maximum duration for reading request headers.
+	ReadHeaderTimeout time.Duration
+	// WriteTimeout is the maximum duration before timing out writes.
+	WriteTimeout time.Duration
+	// IdleTimeout is the maximum amount of time to wait for the next request.
+	IdleTimeout time.Duration
+	// MaxHeaderBytes is the maximum size of request headers.
+	MaxHeaderBytes int
+	// MaxConnections is the maximum number of simultaneously accepted connections.
+	MaxConnections int
+}
+
+// Options returns the ServerOptions equivalent to the configuration.
+func (cfg ServerConfig) Options() []ServerOption {
+	var opts []ServerOption
+
+	if cfg.Port != 0 {
+		opts = append(opts, WithPort(cfg.Port))
+	}
+
+	if cfg.TLSCertPath != "" || cfg.TLSKeyPath != "" {
+		opts = append(opts, WithTLS(cfg.TLSCertPath, cfg.TLSKeyPath))
+	}
+
+	durations := []struct {
+		value  time.Duration
+		option func(time.Duration) ServerOption
+	}{
+		{cfg.ShutdownTimeout, WithShutdownTimeout},
+		{cfg.ShutdownHooksTimeout, WithShutdownHooksTimeout},
+		{cfg.ReadTimeout, WithReadTimeout},
+		{cfg.ReadHeaderTimeout, WithReadHeaderTimeout},
+		{cfg.WriteTimeout, WithWriteTimeout},
+		{cfg.IdleTimeout, WithIdleTimeout},
+	}
+
+	for _, duration := range durations {
+		if duration.value != 0 {
+			opts = append(opts, duration.option(duration.value))
+		}
+	}
+
+	if cfg.MaxHeaderBytes != 0 {
+		opts = append(opts, WithMaxHeaderBytes(cfg.MaxHeaderBytes))
+	}
+
+	if cfg.MaxConnections != 0 {
+		opts = append(opts, WithMaxConnections(cfg.MaxConnections))
+	}
+
+	return opts
+}
+
+// NewServerFromConfig creates a new Server from declarative configuration.
+// Additional options are applied after the configuration, so they take precedence.
+// Returns an error if the resulting server configuration is invalid.
+func NewServerFromConfig(handler http.Handler, cfg ServerConfig, opts ...ServerOption) (*Server, error) {
+	server := NewServer(handler, append(cfg.Options(), opts...)...)
+
+	err := server.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return server, nil
+}
+
 // Validate checks whether the server has enough configuration to start safely.
+// All problems are reported at once, joined into a single error.
 func (s *Server) Validate() error {
-	if s.Addr == "" {
-		return ErrServerAddrRequired
+	var addrErr, tlsErr, clientCertErr error
+
+	if s.Addr == "" && s.listener == nil {
+		addrErr = ErrServerAddrRequired
 	}
 
 	if s.useTLS && (s.certificatePath == "" || s.keyPath == "") {
-		return ErrIncompleteTLSConfig
+		tlsErr = ErrIncompleteTLSConfig
 	}
 
-	return nil
+	if s.clientCertAuth && (!s.useTLS || s.TLSConfig.ClientCAs == nil) {
+		clientCertErr = ErrIncompleteClientCertAuth
+	}
+
+	return joinErrors(addrErr, tlsErr, clientCertErr)
 }
 
 // Run starts the server and blocks until a termination signal is received.
@@ -231,28 +531,100 @@ func (s *Server) Start() error {
 		return fmt.Errorf("validate server config: %w", validateErr)
 	}
 
+	addr := s.Addr
+	if s.listener != nil {
+		addr = s.listener.Addr().String()
+	}
+
 	s.logger.Info(
 		"starting server",
-		slog.String("addr", s.Addr),
+		slog.String("addr", addr),
 		slog.Bool("tls", s.useTLS),
 	)
 
-	var err error
+	listener, err := s.listen()
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	s.startBackgroundLoops()
+
 	if s.useTLS {
-		err = s.ListenAndServeTLS(s.certificatePath, s.keyPath)
+		err = s.ServeTLS(listener, s.certificatePath, s.keyPath)
 		if err != nil {
-			return fmt.Errorf("failed to start TLS server: %w", err)
+			return s.serveFailed(listener, fmt.Errorf("failed to start TLS server: %w", err))
 		}
 	} else {
-		err = s.ListenAndServe()
+		err = s.Serve(listener)
 		if err != nil {
-			return fmt.Errorf("failed to start HTTP server: %w", err)
+			return s.serveFailed(listener, fmt.Errorf("failed to start HTTP server: %w", err))
 		}
 	}
 
 	return nil
 }
 
+// startBackgroundLoops starts the background loops unless the server is already stopping.
+func (s *Server) startBackgroundLoops() {
+	s.loopsMutex.Lock()
+	defer s.loopsMutex.Unlock()
+
+	if s.stopping {
+		return
+	}
+
+	for _, loop := range s.backgroundLoops {
+		loop.Start(context.Background())
+	}
+}
+
+// serveFailed releases what Start acquired before serving: the listener, which ServeTLS
+// leaves open when it cannot load the certificate, and the background loops. After a
+// graceful stop, Serve returns http.ErrServerClosed and StopContext has done this already.
+func (s *Server) serveFailed(listener net.Listener, err error) error {
+	if errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	_ = listener.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+
+	var loopsErr error
+
+	for idx, loop := range slices.Backward(s.backgroundLoops) {
+		loopErr := loop.Shutdown(ctx)
+		if loopErr != nil {
+			loopsErr = errors.Join(loopsErr, fmt.Errorf("background loop %d: %w", idx, loopErr))
+		}
+	}
+
+	return joinErrors(err, loopsErr)
+}
+
+// listen returns the listener to serve on, limited to the maximum number of connections.
+func (s *Server) listen() (net.Listener, error) {
+	listener := s.listener
+	if listener == nil {
+		//nolint:exhaustruct // Zero-value listen config defaults are intended
+		listenConfig := net.ListenConfig{}
+
+		var err error
+
+		listener, err = listenConfig.Listen(context.Background(), "tcp", s.Addr)
+		if err != nil {
+			return nil, fmt.Errorf("listen on %s: %w", s.Addr, err)
+		}
+	}
+
+	if s.maxConnections > 0 {
+		listener = netutil.LimitListener(listener, s.maxConnections)
+	}
+
+	return listener, nil
+}
+
 // Stop gracefully shuts down the server with the configured shutdown timeout.
 func (s *Server) Stop() error {
 	return s.StopContext(context.Background())
@@ -269,15 +641,34 @@ func (s *Server) StopContext(ctx context.Context) error {
 		slog.String("timeout", s.shutdownTimeout.String()),
 	)
 
+	if s.closeOnDrain {
+		s.SetKeepAlivesEnabled(false)
+	}
+
+	preHooksErr := s.runPreShutdownFuncs(ctx)
 	shutdownErr := s.Shutdown(ctx)
 	hooksErr := s.runShutdownFuncsWithTimeout(ctx)
 
 	return joinErrors(
+		preHooksErr,
 		wrapIfError(shutdownErr, "shutdown failed"),
 		hooksErr,
 	)
 }
 
+func (s *Server) runPreShutdownFuncs(ctx context.Context) error {
+	var runErr error
+
+	for idx, preShutdownFunc := range s.preShutdownFuncs {
+		err := preShutdownFunc(ctx)
+		if err != nil {
+			runErr = errors.Join(runErr, fmt.Errorf("pre-shutdown hook %d: %w", idx, err))
+		}
+	}
+
+	return runErr
+}
+
 func (s *Server) runShutdownFuncsWithTimeout(ctx context.Context) error {
 	if s.shutdownHooksTimeout > 0 {
 		var cancel context.CancelFunc
@@ -295,23 +686,19 @@ func (s *Server) runShutdownFuncsWithTimeout(ctx context.Context) error {
 }
 
 func (s *Server) runShutdownFuncs(ctx context.Context) error {
+	s.loopsMutex.Lock()
+	s.stopping = true
+	s.loopsMutex.Unlock()
+
 	s.shutdownOnce.Do(func() {
 		var runErr error
 
 		for idx, shutdownFunc := range slices.Backward(s.shutdownFuncs) {
-			func(hookIndex int) {
-				defer func() {
-					if recovered := recover(); recovered != nil {
-						panicErr := fmt.Errorf("%w: hook %d: %v", ErrShutdownHookPanic, hookIndex, recovered)
-						runErr = errors.Join(runErr, panicErr)
-					}
-				}()
-
-				err := shutdownFunc(ctx)
-				if err != nil {
-					runErr = errors.Join(runErr, fmt.Errorf("shutdown hook %d: %w", hookIndex, err))
-				}
-			}(idx)
+			runErr = errors.Join(runErr, callShutdownFunc(ctx, shutdownFunc, fmt.Sprintf("shutdown hook %d", idx)))
+		}
+
+		for idx, flusher := range s.flushers {
+			runErr = errors.Join(runErr, callShutdownFunc(ctx, flusher.Flush, fmt.Sprintf("flusher %d", idx)))
 		}
 
 		s.shutdownErr = runErr
@@ -320,6 +707,36 @@ func(hookIndex int) {
 	return s.shutdownErr
 }
 
+// callShutdownFunc runs fn, converting a panic into an error wrapping ErrShutdownHookPanic.
+func callShutdownFunc(
+	ctx context.Context,
+	fn ShutdownFunc,
+	label string,
+) (err error) { //nolint:nonamedreturns // Set by recover.
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("%w: %s: %v", ErrShutdownHookPanic, label, recovered)
+		}
+	}()
+
+	err = fn(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", label, err)
+	}
+
+	return nil
+}
+
+// VerifiedClientCertificate returns the verified leaf certificate the client presented,
+// or false if the request was not made over mutual TLS.
+func VerifiedClientCertificate(req *http.Request) (*x509.Certificate, bool) {
+	if req.TLS == nil || len(req.TLS.VerifiedChains) == 0 || len(req.TLS.VerifiedChains[0]) == 0 {
+		return nil, false
+	}
+
+	return req.TLS.VerifiedChains[0][0], true
+}
+
 func wrapIfError(err error, message string) error {
 	if err == nil {
 		return nil
@@ -1,13 +1,20 @@
 package vital_test
 
 import (
+	"bufio"
 	"context"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"log/slog"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"os"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -139,6 +146,151 @@ func TestNewServer(t *testing.T) {
 		expectedAddr := fmt.Sprintf(":%d", port)
 		testastic.Equal(t, expectedAddr, server.Addr)
 	})
+
+	t.Run("mounts health endpoints in front of handler", func(t *testing.T) {
+		t.Parallel()
+
+		// given: an application handler and a server with health endpoints
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		})
+
+		server := vital.NewServer(handler, vital.WithHealth(vital.WithVersion("1.2.3")))
+
+		serve := func(path string) *httptest.ResponseRecorder {
+			responseRecorder := httptest.NewRecorder()
+			req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, path, nil)
+
+			server.Handler.ServeHTTP(responseRecorder, req)
+
+			return responseRecorder
+		}
+
+		// when: requesting health and application paths
+		ready := serve("/readyz")
+		app := serve("/api/hello")
+
+		// then: health paths should be served by the health handler and others by the application
+		testastic.Equal(t, http.StatusOK, ready.Code)
+
+		var response vital.ReadyResponse
+
+		err := json.NewDecoder(ready.Body).Decode(&response)
+		testastic.NoError(t, err)
+		testastic.Equal(t, "1.2.3", response.Version)
+
+		testastic.Equal(t, http.StatusOK, serve("/livez").Code)
+		testastic.Equal(t, http.StatusOK, serve("/startupz").Code)
+		testastic.Equal(t, http.StatusTeapot, app.Code)
+		testastic.Equal(t, http.StatusTeapot, serve("/version").Code)
+	})
+
+	t.Run("passes other requests to the handler unchanged", func(t *testing.T) {
+		t.Parallel()
+
+		// given: an application handler that records what it receives
+		var (
+			gotPath    string
+			gotPattern string
+		)
+
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotPath = r.URL.Path
+			gotPattern = r.Pattern
+
+			w.WriteHeader(http.StatusTeapot)
+		})
+
+		server := vital.NewServer(handler, vital.WithHealth())
+
+		serve := func(method, path string) int {
+			responseRecorder := httptest.NewRecorder()
+			req := httptest.NewRequestWithContext(context.Background(), method, path, nil)
+
+			server.Handler.ServeHTTP(responseRecorder, req)
+
+			return responseRecorder.Code
+		}
+
+		// when: requesting a non-clean path
+		code := serve(http.MethodGet, "/a//b")
+
+		// then: the handler should receive the path as sent, without a pattern set
+		testastic.Equal(t, http.StatusTeapot, code)
+		testastic.Equal(t, "/a//b", gotPath)
+		testastic.Equal(t, "", gotPattern)
+
+		// and: non-GET requests to health paths should reach the handler too
+		testastic.Equal(t, http.StatusTeapot, serve(http.MethodPost, "/livez"))
+	})
+}
+
+func TestNewServerFromConfig(t *testing.T) {
+	t.Parallel()
+	t.Run("applies config values", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a declarative server config
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		cfg := vital.ServerConfig{
+			Port:           8080,
+			ReadTimeout:    5 * time.Second,
+			WriteTimeout:   15 * time.Second,
+			MaxHeaderBytes: 4096,
+		}
+
+		// when: creating a server from config
+		server, err := vital.NewServerFromConfig(handler, cfg)
+
+		// then: it should use the configured values and keep defaults for the rest
+		testastic.NoError(t, err)
+		testastic.Equal(t, ":8080", server.Addr)
+		testastic.Equal(t, 5*time.Second, server.ReadTimeout)
+		testastic.Equal(t, 15*time.Second, server.WriteTimeout)
+		testastic.Equal(t, 10*time.Second, server.ReadHeaderTimeout)
+		testastic.Equal(t, 120*time.Second, server.IdleTimeout)
+		testastic.Equal(t, 4096, server.MaxHeaderBytes)
+	})
+
+	t.Run("options take precedence over config", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a config and an overriding option
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		cfg := vital.ServerConfig{Port: 8080}
+
+		// when: creating a server from config with an extra option
+		server, err := vital.NewServerFromConfig(handler, cfg, vital.WithPort(9090))
+
+		// then: the option should win
+		testastic.NoError(t, err)
+		testastic.Equal(t, ":9090", server.Addr)
+	})
+
+	t.Run("returns validation errors", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a config without port and with incomplete TLS
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		cfg := vital.ServerConfig{TLSCertPath: "testdata/server.crt"}
+
+		// when: creating a server from config
+		server, err := vital.NewServerFromConfig(handler, cfg)
+
+		// then: it should report all problems
+		testastic.Nil(t, server)
+		testastic.ErrorIs(t, err, vital.ErrServerAddrRequired)
+		testastic.ErrorIs(t, err, vital.ErrIncompleteTLSConfig)
+	})
 }
 
 func TestServer_Validate(t *testing.T) {
@@ -180,6 +332,42 @@ func TestServer_Validate(t *testing.T) {
 		// then: it should fail before trying to listen
 		testastic.ErrorIs(t, err, vital.ErrIncompleteTLSConfig)
 	})
+
+	t.Run("requires TLS and CA pool for client cert auth", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a server with client cert auth but without TLS or a CA pool
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		server := vital.NewServer(handler, vital.WithPort(getAvailablePort(t)), vital.WithClientCertAuth(nil))
+
+		// when: validating the server
+		err := server.Validate()
+
+		// then: it should fail before trying to listen
+		testastic.ErrorIs(t, err, vital.ErrIncompleteClientCertAuth)
+	})
+
+	t.Run("reports all problems at once", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a server without an address and with incomplete TLS config
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		server := vital.NewServer(handler, vital.WithTLS("testdata/server.crt", ""))
+
+		// when: validating the server
+		err := server.Validate()
+
+		// then: both errors should be reported
+		testastic.ErrorIs(t, err, vital.ErrServerAddrRequired)
+
+		testastic.ErrorIs(t, err, vital.ErrIncompleteTLSConfig)
+	})
 }
 
 func TestServer_HTTP(t *testing.T) {
@@ -251,6 +439,123 @@ func TestServer_HTTP(t *testing.T) {
 		default:
 		}
 	})
+
+	t.Run("serves on a provided listener", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a server without an address that serves on an existing listener
+		listenConfig := net.ListenConfig{}
+
+		listener, err := listenConfig.Listen(context.Background(), "tcp", "127.0.0.1:0")
+		testastic.NoError(t, err)
+
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		})
+
+		server := vital.NewServer(
+			handler,
+			vital.WithListener(listener),
+			vital.WithLogger(slog.New(slog.DiscardHandler)),
+		)
+
+		go func() {
+			_ = server.Start()
+		}()
+
+		defer func() { _ = server.Stop() }()
+
+		serverURL := "http://" + listener.Addr().String()
+		waitForServer(t, serverURL)
+
+		// when: making an HTTP request to the listener address
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, serverURL, nil)
+		testastic.NoError(t, err)
+
+		resp, err := http.DefaultClient.Do(req)
+		testastic.NoError(t, err)
+
+		defer func() { _ = resp.Body.Close() }()
+
+		// then: the request should be served by the handler
+		testastic.Equal(t, http.StatusTeapot, resp.StatusCode)
+	})
+
+	t.Run("limits simultaneous connections", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a server that accepts a single connection at a time
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		var newConns atomic.Int32
+
+		port := getAvailablePort(t)
+		server := vital.NewServer(
+			handler,
+			vital.WithPort(port),
+			vital.WithMaxConnections(1),
+			vital.WithConnState(func(_ net.Conn, state http.ConnState) {
+				if state == http.StateNew {
+					newConns.Add(1)
+				}
+			}),
+			vital.WithLogger(slog.New(slog.DiscardHandler)),
+		)
+
+		go func() {
+			_ = server.Start()
+		}()
+
+		defer func() { _ = server.Stop() }()
+
+		addr := fmt.Sprintf("localhost:%d", port)
+		dialer := net.Dialer{}
+
+		var held net.Conn
+
+		for range 50 {
+			conn, err := dialer.DialContext(context.Background(), "tcp", addr)
+			if err == nil {
+				held = conn
+
+				break
+			}
+
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		testastic.NotNil(t, held)
+
+		for newConns.Load() < 1 {
+			time.Sleep(time.Millisecond)
+		}
+
+		// when: a second client sends a request while the first connection is open
+		waiting, err := dialer.DialContext(context.Background(), "tcp", addr)
+		testastic.NoError(t, err)
+
+		defer func() { _ = waiting.Close() }()
+
+		_, err = waiting.Write([]byte("GET / HTTP/1.1\r\nHost: localhost\r\nConnection: close\r\n\r\n"))
+		testastic.NoError(t, err)
+
+		time.Sleep(100 * time.Millisecond)
+
+		// then: it should only be accepted once the first connection closes
+		testastic.Equal(t, int32(1), newConns.Load())
+
+		_ = held.Close()
+
+		resp, err := http.ReadResponse(bufio.NewReader(waiting), nil)
+		testastic.NoError(t, err)
+
+		_ = resp.Body.Close()
+
+		testastic.Equal(t, http.StatusOK, resp.StatusCode)
+		testastic.Equal(t, int32(2), newConns.Load())
+	})
 }
 
 func TestServer_Stop(t *testing.T) {
@@ -350,6 +655,354 @@ func TestServer_Stop(t *testing.T) {
 		testastic.SliceEqual(t, []string{"second", "first"}, calls)
 	})
 
+	t.Run("runs pre-shutdown funcs before shutdown funcs", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a running server with pre-shutdown and shutdown hooks
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		port := getAvailablePort(t)
+		lifecycle := vital.NewLifecycle()
+		lifecycle.SetReady()
+
+		var (
+			mu    sync.Mutex
+			calls []string
+		)
+
+		record := func(name string) vital.ShutdownFunc {
+			return func(context.Context) error {
+				mu.Lock()
+				defer mu.Unlock()
+
+				calls = append(calls, name)
+
+				return nil
+			}
+		}
+
+		server := vital.NewServer(
+			handler,
+			vital.WithPort(port),
+			vital.WithPreShutdownFunc(lifecycle.Shutdown),
+			vital.WithPreShutdownFunc(record("pre")),
+			vital.WithShutdownFunc(record("post")),
+			vital.WithLogger(slog.New(slog.DiscardHandler)),
+		)
+
+		go func() {
+			_ = server.Start()
+		}()
+
+		waitForServer(t, fmt.Sprintf("http://localhost:%d", port))
+
+		// when: stopping the server
+		err := server.Stop()
+
+		// then: readiness should be failed and hooks should run in phase order
+		testastic.NoError(t, err)
+		testastic.Equal(t, vital.LifecycleShuttingDown, lifecycle.State())
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		testastic.SliceEqual(t, []string{"pre", "post"}, calls)
+	})
+
+	t.Run("drains with failing readiness before shutdown", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a running server with health endpoints and a drain period
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		port := getAvailablePort(t)
+		lifecycle := vital.NewLifecycle()
+		lifecycle.SetReady()
+
+		drainPeriod := 300 * time.Millisecond
+
+		server := vital.NewServer(
+			handler,
+			vital.WithPort(port),
+			vital.WithHealth(vital.WithLifecycle(lifecycle)),
+			vital.WithDrainPeriod(lifecycle, drainPeriod),
+			vital.WithLogger(slog.New(slog.DiscardHandler)),
+		)
+
+		go func() {
+			_ = server.Start()
+		}()
+
+		readyURL := fmt.Sprintf("http://localhost:%d/readyz", port)
+		waitForServer(t, readyURL)
+
+		// when: stopping the server
+		stopped := make(chan error, 1)
+		startedAt := time.Now()
+
+		go func() {
+			stopped <- server.Stop()
+		}()
+
+		// then: readiness should fail while the server still accepts requests
+		var drainingStatus int
+
+		for range 20 {
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, readyURL, nil)
+			testastic.NoError(t, err)
+
+			resp, err := http.DefaultClient.Do(req)
+			testastic.NoError(t, err)
+
+			_ = resp.Body.Close()
+
+			drainingStatus = resp.StatusCode
+			if drainingStatus == http.StatusServiceUnavailable {
+				break
+			}
+
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		testastic.Equal(t, http.StatusServiceUnavailable, drainingStatus)
+
+		err := <-stopped
+		testastic.NoError(t, err)
+		testastic.GreaterOrEqual(t, time.Since(startedAt), drainPeriod)
+	})
+
+	t.Run("closes connections during drain when keep-alives are disabled", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a running server that disables keep-alives during a drain period
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		port := getAvailablePort(t)
+		lifecycle := vital.NewLifecycle()
+
+		server := vital.NewServer(
+			handler,
+			vital.WithPort(port),
+			vital.WithDrainPeriod(lifecycle, 300*time.Millisecond),
+			vital.WithDisableKeepAlivesDuringDrain(),
+			vital.WithLogger(slog.New(slog.DiscardHandler)),
+		)
+
+		go func() {
+			_ = server.Start()
+		}()
+
+		serverURL := fmt.Sprintf("http://localhost:%d", port)
+		waitForServer(t, serverURL)
+
+		// when: sending a request while the server drains
+		stopped := make(chan error, 1)
+
+		go func() {
+			stopped <- server.Stop()
+		}()
+
+		for lifecycle.State() != vital.LifecycleShuttingDown {
+			time.Sleep(time.Millisecond)
+		}
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, serverURL, nil)
+		testastic.NoError(t, err)
+
+		resp, err := http.DefaultClient.Do(req)
+		testastic.NoError(t, err)
+
+		_ = resp.Body.Close()
+
+		// then: the response should ask the client to close the connection
+		testastic.Equal(t, http.StatusOK, resp.StatusCode)
+		testastic.True(t, resp.Close)
+		testastic.NoError(t, <-stopped)
+	})
+
+	t.Run("starts background loops and stops them after the server", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a server with a background loop
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		started := make(chan struct{})
+		stopped := make(chan struct{})
+
+		loop := vital.NewBackgroundLoop(func(ctx context.Context) {
+			close(started)
+			<-ctx.Done()
+			close(stopped)
+		})
+
+		port := getAvailablePort(t)
+		server := vital.NewServer(
+			handler,
+			vital.WithPort(port),
+			vital.WithBackgroundLoops(loop),
+			vital.WithLogger(slog.New(slog.DiscardHandler)),
+		)
+
+		// when: starting and stopping the server
+		go func() {
+			_ = server.Start()
+		}()
+
+		waitForServer(t, fmt.Sprintf("http://localhost:%d", port))
+
+		select {
+		case <-started:
+		case <-time.After(time.Second):
+			t.Fatal("background loop was not started")
+		}
+
+		err := server.Stop()
+
+		// then: the loop should have been stopped and awaited
+		testastic.NoError(t, err)
+
+		select {
+		case <-stopped:
+		default:
+			t.Fatal("background loop was not stopped")
+		}
+	})
+
+	t.Run("stops background loops when serving fails", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a TLS server with a missing key file and a background loop
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		stopped := make(chan struct{})
+
+		loop := vital.NewBackgroundLoop(func(ctx context.Context) {
+			<-ctx.Done()
+			close(stopped)
+		})
+
+		server := vital.NewServer(
+			handler,
+			vital.WithPort(getAvailablePort(t)),
+			vital.WithTLS("testdata/server.crt", "testdata/missing.key"),
+			vital.WithBackgroundLoops(loop),
+			vital.WithLogger(slog.New(slog.DiscardHandler)),
+		)
+
+		// when: starting the server
+		err := server.Start()
+
+		// then: start should fail and the loop should have been stopped
+		testastic.ErrorIs(t, err, fs.ErrNotExist)
+
+		select {
+		case <-stopped:
+		default:
+			t.Fatal("background loop was not stopped")
+		}
+	})
+
+	t.Run("does not start background loops after stopping", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a server with a background loop that was stopped before it started
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		loop := vital.NewBackgroundLoop(func(ctx context.Context) {
+			<-ctx.Done()
+		})
+
+		server := vital.NewServer(
+			handler,
+			vital.WithPort(getAvailablePort(t)),
+			vital.WithBackgroundLoops(loop),
+			vital.WithLogger(slog.New(slog.DiscardHandler)),
+		)
+
+		testastic.NoError(t, server.Stop())
+
+		// when: starting the server afterwards, as RunContext does when shutdown wins the race
+		err := server.Start()
+
+		// then: serving should report the closed server and the loop should never have started
+		testastic.ErrorIs(t, err, http.ErrServerClosed)
+		testastic.ErrorIs(t, loop.Restart(t.Context()), vital.ErrBackgroundLoopNotStarted)
+	})
+
+	t.Run("flushes after shutdown hooks", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a server with a shutdown hook and two flushers, one failing
+		var (
+			mu    sync.Mutex
+			calls []string
+		)
+
+		record := func(name string, err error) vital.ShutdownFunc {
+			return func(context.Context) error {
+				mu.Lock()
+				defer mu.Unlock()
+
+				calls = append(calls, name)
+
+				return err
+			}
+		}
+
+		flushErr := errors.New("flush failed")
+
+		server := vital.NewServer(
+			http.NotFoundHandler(),
+			vital.WithFlushers(testFlusher(record("flush-1", nil)), testFlusher(record("flush-2", flushErr))),
+			vital.WithShutdownFunc(record("hook", nil)),
+			vital.WithLogger(slog.New(slog.DiscardHandler)),
+		)
+
+		// when: stopping the server
+		err := server.Stop()
+
+		// then: flushers should run after hooks in registration order and report errors
+		testastic.ErrorIs(t, err, flushErr)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		testastic.SliceEqual(t, []string{"hook", "flush-1", "flush-2"}, calls)
+	})
+
+	t.Run("returns error when drain exceeds shutdown timeout", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a server whose drain period exceeds the shutdown timeout
+		lifecycle := vital.NewLifecycle()
+
+		server := vital.NewServer(
+			http.NotFoundHandler(),
+			vital.WithShutdownTimeout(50*time.Millisecond),
+			vital.WithDrainPeriod(lifecycle, time.Minute),
+			vital.WithLogger(slog.New(slog.DiscardHandler)),
+		)
+
+		// when: stopping the server
+		err := server.Stop()
+
+		// then: the interrupted drain should be reported
+		testastic.ErrorIs(t, err, context.DeadlineExceeded)
+		testastic.Equal(t, vital.LifecycleShuttingDown, lifecycle.State())
+	})
+
 	t.Run("returns shutdown hook errors", func(t *testing.T) {
 		t.Parallel()
 
@@ -821,6 +1474,97 @@ func TestServerIntegration_HTTPS(t *testing.T) {
 }
 
 // ExampleNewServer demonstrates creating a basic HTTP server with options.
+func TestServerIntegration_MutualTLS(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	t.Run("requires and exposes verified client certificate", func(t *testing.T) {
+		t.Parallel()
+
+		// given: an HTTPS server requiring client certificates from the test CA
+		caPEM, err := os.ReadFile("testdata/server.crt")
+		testastic.NoError(t, err)
+
+		clientCAs := x509.NewCertPool()
+		testastic.True(t, clientCAs.AppendCertsFromPEM(caPEM))
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/whoami", func(w http.ResponseWriter, r *http.Request) {
+			cert, ok := vital.VerifiedClientCertificate(r)
+			if !ok {
+				w.WriteHeader(http.StatusUnauthorized)
+
+				return
+			}
+
+			_, _ = w.Write([]byte(cert.Subject.CommonName))
+		})
+
+		port := getAvailablePort(t)
+		server := vital.NewServer(
+			mux,
+			vital.WithPort(port),
+			vital.WithTLS("testdata/server.crt", "testdata/server.key"),
+			vital.WithClientCertAuth(clientCAs),
+			vital.WithLogger(slog.New(slog.DiscardHandler)),
+		)
+
+		go func() {
+			_ = server.Start()
+		}()
+
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+			defer cancel()
+
+			_ = server.Shutdown(ctx)
+		}()
+
+		clientCert, err := tls.LoadX509KeyPair("testdata/server.crt", "testdata/server.key")
+		testastic.NoError(t, err)
+
+		newClient := func(certs ...tls.Certificate) *http.Client {
+			return &http.Client{
+				Timeout: 2 * time.Second,
+				Transport: &http.Transport{
+					TLSClientConfig: &tls.Config{
+						InsecureSkipVerify: true,
+						Certificates:       certs,
+					},
+				},
+			}
+		}
+
+		url := fmt.Sprintf("https://localhost:%d/whoami", port)
+		waitForServerWithClient(t, newClient(clientCert), url)
+
+		// when: calling with and without a client certificate
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+		testastic.NoError(t, err)
+
+		resp, err := newClient(clientCert).Do(req)
+		testastic.NoError(t, err)
+
+		defer func() { _ = resp.Body.Close() }()
+
+		body, err := io.ReadAll(resp.Body)
+		testastic.NoError(t, err)
+
+		anonymousResp, anonymousErr := newClient().Do(req)
+		if anonymousErr == nil {
+			_ = anonymousResp.Body.Close()
+		}
+
+		// then: only the request with a certificate should succeed and see its subject
+		testastic.Equal(t, http.StatusOK, resp.StatusCode)
+		testastic.Equal(t, "localhost", string(body))
+		testastic.Error(t, anonymousErr)
+	})
+}
+
 func ExampleNewServer() {
 	// Create a simple handler
 	mux := http.NewServeMux()
@@ -857,12 +1601,14 @@ func getAvailablePort(t *testing.T) int {
 	return basePort + int(testPortCounter.Add(1))
 }
 
+// testFlusher adapts a function to vital.Flusher.
+type testFlusher func(context.Context) error
+
+func (f testFlusher) Flush(ctx context.Context) error { return f(ctx) }
+
 func waitForServer(t *testing.T, url string) {
 	t.Helper()
 
-	// Give the server goroutine a moment to start
-	time.Sleep(50 * time.Millisecond)
-
 	client := &http.Client{
 		Timeout: 500 * time.Millisecond,
 		Transport: &http.Transport{
@@ -872,6 +1618,15 @@ func waitForServer(t *testing.T, url string) {
 		},
 	}
 
+	waitForServerWithClient(t, client, url)
+}
+
+func waitForServerWithClient(t *testing.T, client *http.Client, url string) {
+	t.Helper()
+
+	// Give the server goroutine a moment to start
+	time.Sleep(50 * time.Millisecond)
+
 	maxAttempts := 50
 
 	for range maxAttempts {
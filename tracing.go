@@ -0,0 +1,37 @@
+package vital
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope used for spans created by vital.
+const tracerName = "github.com/monkescience/vital"
+
+// StartSpan starts a child span of the span stored in ctx and returns the new context and span.
+// The span is created with the tracer provider of the parent span when ctx carries a recording
+// one, falling back to the global tracer provider otherwise. Callers must end the returned span.
+//
+//nolint:spancheck // The caller owns the returned span and is responsible for ending it.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer(ctx).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// AddSpanEvent records an event on the span stored in ctx.
+// It is a no-op when ctx carries no recording span.
+func AddSpanEvent(ctx context.Context, name string, attrs ...attribute.KeyValue) {
+	trace.SpanFromContext(ctx).AddEvent(name, trace.WithAttributes(attrs...))
+}
+
+func tracer(ctx context.Context) trace.Tracer {
+	provider := otel.GetTracerProvider()
+
+	if span := trace.SpanFromContext(ctx); span.IsRecording() {
+		provider = span.TracerProvider()
+	}
+
+	return provider.Tracer(tracerName)
+}
@@ -0,0 +1,214 @@
+package vital_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/monkescience/testastic"
+	"github.com/monkescience/vital"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// recordingProvider is a minimal recording tracer provider built on the otel/trace API,
+// avoiding a direct dependency on the OTel SDK.
+type recordingProvider struct {
+	noop.TracerProvider
+
+	mu    sync.Mutex
+	spans []*recordingSpan
+}
+
+func (p *recordingProvider) Tracer(name string, _ ...trace.TracerOption) trace.Tracer {
+	return &recordingTracer{provider: p, scope: name}
+}
+
+func (p *recordingProvider) started() []*recordingSpan {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]*recordingSpan(nil), p.spans...)
+}
+
+type recordingTracer struct {
+	noop.Tracer
+
+	provider *recordingProvider
+	scope    string
+}
+
+func (t *recordingTracer) Start(
+	ctx context.Context,
+	name string,
+	opts ...trace.SpanStartOption,
+) (context.Context, trace.Span) {
+	cfg := trace.NewSpanStartConfig(opts...)
+	parent := trace.SpanContextFromContext(ctx)
+
+	t.provider.mu.Lock()
+	defer t.provider.mu.Unlock()
+
+	span := &recordingSpan{
+		provider: t.provider,
+		name:     name,
+		scope:    t.scope,
+		attrs:    cfg.Attributes(),
+		parent:   parent,
+		spanCtx: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    parent.TraceID(),
+			SpanID:     trace.SpanID{0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10, byte(len(t.provider.spans))},
+			TraceFlags: trace.FlagsSampled,
+		}),
+	}
+	t.provider.spans = append(t.provider.spans, span)
+
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+type recordingSpan struct {
+	noop.Span
+
+	provider *recordingProvider
+	name     string
+	scope    string
+	attrs    []attribute.KeyValue
+	parent   trace.SpanContext
+	spanCtx  trace.SpanContext
+
+	mu     sync.Mutex
+	events []recordedEvent
+	ended  bool
+}
+
+type recordedEvent struct {
+	name  string
+	attrs []attribute.KeyValue
+}
+
+func (s *recordingSpan) SpanContext() trace.SpanContext { return s.spanCtx }
+
+func (s *recordingSpan) IsRecording() bool { return true }
+
+func (s *recordingSpan) TracerProvider() trace.TracerProvider { return s.provider }
+
+func (s *recordingSpan) AddEvent(name string, opts ...trace.EventOption) {
+	cfg := trace.NewEventConfig(opts...)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events = append(s.events, recordedEvent{name: name, attrs: cfg.Attributes()})
+}
+
+// RecordError records err as an "exception" event, mirroring the SDK's semantic conventions.
+func (s *recordingSpan) RecordError(err error, opts ...trace.EventOption) {
+	opts = append(opts, trace.WithAttributes(attribute.String("exception.message", err.Error())))
+	s.AddEvent("exception", opts...)
+}
+
+func (s *recordingSpan) End(...trace.SpanEndOption) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ended = true
+}
+
+func (s *recordingSpan) recordedEvents() []recordedEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]recordedEvent(nil), s.events...)
+}
+
+// testRecordingSpan starts a recording root span and returns a context carrying it.
+func testRecordingSpan(tb testing.TB) (context.Context, *recordingProvider, *recordingSpan) {
+	tb.Helper()
+
+	provider := &recordingProvider{}
+	parentCtx, _ := testSpanContext(tb)
+
+	ctx, span := provider.Tracer("test").Start(parentCtx, "root")
+
+	recording, ok := span.(*recordingSpan)
+	if !ok {
+		tb.Fatal("expected a recording span")
+	}
+
+	return ctx, provider, recording
+}
+
+func TestStartSpan(t *testing.T) {
+	t.Parallel()
+	t.Run("starts child span with parent tracer provider", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a context carrying a recording span
+		ctx, provider, root := testRecordingSpan(t)
+
+		// when: starting a child span with attributes
+		childCtx, child := vital.StartSpan(ctx, "load user", attribute.String("user.id", "42"))
+		child.End()
+
+		// then: the child span should be created by the parent's provider under vital's scope
+		spans := provider.started()
+		testastic.Equal(t, 2, len(spans))
+
+		created := spans[1]
+		testastic.Equal(t, "load user", created.name)
+		testastic.Equal(t, "github.com/monkescience/vital", created.scope)
+		testastic.Equal(t, root.spanCtx.SpanID(), created.parent.SpanID())
+		testastic.DeepEqual(t, []attribute.KeyValue{attribute.String("user.id", "42")}, created.attrs)
+		testastic.True(t, created.ended)
+
+		// and: the returned context should carry the child span
+		testastic.Equal(t, created.spanCtx.SpanID(), trace.SpanContextFromContext(childCtx).SpanID())
+	})
+
+	t.Run("falls back to global provider without recording span", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a context without a span
+
+		// when: starting a span
+		ctx, span := vital.StartSpan(context.Background(), "background work")
+		defer span.End()
+
+		// then: a span should still be returned and usable for events
+		testastic.NotNil(t, span)
+		testastic.NotNil(t, trace.SpanFromContext(ctx))
+
+		vital.AddSpanEvent(ctx, "step done")
+	})
+}
+
+func TestAddSpanEvent(t *testing.T) {
+	t.Parallel()
+	t.Run("records event on span in context", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a context carrying a recording span
+		ctx, _, root := testRecordingSpan(t)
+
+		// when: adding a span event
+		vital.AddSpanEvent(ctx, "cache miss", attribute.String("cache.key", "user:42"))
+
+		// then: the event should be recorded on the span
+		events := root.recordedEvents()
+		testastic.Equal(t, 1, len(events))
+		testastic.Equal(t, "cache miss", events[0].name)
+		testastic.DeepEqual(t, []attribute.KeyValue{attribute.String("cache.key", "user:42")}, events[0].attrs)
+	})
+
+	t.Run("is a no-op without span", func(t *testing.T) {
+		t.Parallel()
+
+		// given: a context without a span
+
+		// when: adding a span event
+		vital.AddSpanEvent(context.Background(), "ignored")
+
+		// then: it should not panic
+	})
+}